@@ -0,0 +1,87 @@
+package java2json
+
+import "strconv"
+
+// ClassCatalog contains the class descriptors read from a serialized stream.
+type ClassCatalog struct {
+	Classes []ClassDescriptor `json:"classes"`
+}
+
+// ClassDescriptor describes a serializable class as written in a serialized stream,
+// the serialVersionUID is marshalled as a string like LongString as it is often beyond the JavaScript safe integer range.
+type ClassDescriptor struct {
+	Name             string            `json:"name"`
+	SerialVersionUID int64             `json:"serialVersionUID,string"`
+	Flags            uint8             `json:"flags"`
+	Enum             bool              `json:"enum,omitempty"`
+	Record           bool              `json:"record,omitempty"`
+	Fields           []FieldDescriptor `json:"fields"`
+	Super            string            `json:"super,omitempty"`
+}
+
+// FieldDescriptor describes a serializable field of a class.
+type FieldDescriptor struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Signature string `json:"signature"`
+}
+
+// ClassCatalog returns the class descriptors read by the parser in stream order,
+// descriptors written again after a stream reset are only included once.
+func (jop *JavaObjectParser) ClassCatalog() *ClassCatalog {
+	catalog := &ClassCatalog{Classes: []ClassDescriptor{}}
+	seen := map[string]bool{}
+
+	for _, cls := range jop.classes {
+		if key := cls.name + "@" + cls.serialVersionUID; !seen[key] {
+			seen[key] = true
+			catalog.Classes = append(catalog.Classes, newClassDescriptor(cls))
+		}
+	}
+
+	return catalog
+}
+
+// Class returns the descriptor of the named class, if any.
+func (c *ClassCatalog) Class(name string) (ClassDescriptor, bool) {
+	for _, cd := range c.Classes {
+		if cd.Name == name {
+			return cd, true
+		}
+	}
+
+	return ClassDescriptor{}, false
+}
+
+// newClassDescriptor converts a class descriptor into its exported form.
+func newClassDescriptor(cls *clazz) ClassDescriptor {
+	suid, _ := strconv.ParseUint(cls.serialVersionUID, 16, 64)
+
+	cd := ClassDescriptor{
+		Name:             cls.name,
+		SerialVersionUID: int64(suid),
+		Flags:            cls.flags,
+		Enum:             cls.isEnum,
+		Record:           cls.isRecord,
+		Fields:           make([]FieldDescriptor, 0, len(cls.fields)),
+	}
+
+	for _, f := range cls.fields {
+		if f == nil {
+			continue
+		}
+
+		signature := f.typeName
+		if f.className != "" {
+			signature = f.className
+		}
+
+		cd.Fields = append(cd.Fields, FieldDescriptor{Name: f.name, Type: javaTypeName(signature), Signature: signature})
+	}
+
+	if cls.super != nil {
+		cd.Super = cls.super.name
+	}
+
+	return cd
+}
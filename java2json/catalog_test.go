@@ -0,0 +1,36 @@
+package java2json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func TestClassCatalog(t *testing.T) {
+	input := "rO0ABXNyAA1jb20uZm9vLk9yZGVy//////////4DAANJAAJpZEwABWl0ZW1zdAAQTGphdmEvdXRpbC9MaXN0O0wABHRhZ3N0ABNbTGphdmEvbGFuZy9TdHJpbmc7eHIADGNvbS5mb28uQmFzZQAAAAAAAAABAgABSgAHY3JlYXRlZHhwAAAAAAAAAAUAAAABc3IAE2phdmEudXRpbC5BcnJheUxpc3R4gdIdmcdhnQMAAUkABHNpemV4cAAAAAF3BAAAAAF0AAFheHVyABNbTGphdmEubGFuZy5TdHJpbmc7rdJW5+kde0cCAAB4cAAAAAF0AAF0eA=="
+	expected := `{"classes":[` +
+		`{"name":"com.foo.Order","serialVersionUID":"-2","flags":3,"fields":[{"name":"id","type":"int","signature":"I"},{"name":"items","type":"java.util.List","signature":"Ljava/util/List;"},{"name":"tags","type":"java.lang.String[]","signature":"[Ljava/lang/String;"}],"super":"com.foo.Base"},` +
+		`{"name":"com.foo.Base","serialVersionUID":"1","flags":2,"fields":[{"name":"created","type":"long","signature":"J"}]},` +
+		`{"name":"java.util.ArrayList","serialVersionUID":"8683452581122892189","flags":3,"fields":[{"name":"size","type":"int","signature":"I"}]},` +
+		`{"name":"[Ljava.lang.String;","serialVersionUID":"-5921575005990323385","flags":2,"fields":[]}]}`
+
+	buf, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jop := NewJavaObjectParser(bytes.NewReader(buf))
+	if _, err = jop.ParseJavaObject(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(jop.ClassCatalog())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != expected {
+		t.Errorf("unexpected catalog\nwant: %s\ngot:  %s", expected, b)
+	}
+}
@@ -0,0 +1,207 @@
+package java2json
+
+import "fmt"
+
+// ChangeKind identifies a kind of class change between two class catalogs.
+type ChangeKind string
+
+const (
+	// SerialVersionUIDChanged is a serialVersionUID mismatch, records are exempt from matching it.
+	SerialVersionUIDChanged ChangeKind = "serialVersionUIDChanged"
+	// FieldAdded is a field missing from the old class, it is set to its default value when reading old streams.
+	FieldAdded ChangeKind = "fieldAdded"
+	// FieldRemoved is a field missing from the new class, old classes reading new streams lose its value.
+	FieldRemoved ChangeKind = "fieldRemoved"
+	// FieldTypeChanged is a change of the declared type of a field.
+	FieldTypeChanged ChangeKind = "fieldTypeChanged"
+	// WriteMethodChanged is a writeObject method added to or removed from the class.
+	WriteMethodChanged ChangeKind = "writeMethodChanged"
+	// SerializationKindChanged is a change between Serializable and Externalizable.
+	SerializationKindChanged ChangeKind = "serializationKindChanged"
+	// EnumKindChanged is a change from an enum type to a non enum type or vice versa.
+	EnumKindChanged ChangeKind = "enumKindChanged"
+	// SuperclassAdded is a class added to the serializable superclasses.
+	SuperclassAdded ChangeKind = "superclassAdded"
+	// SuperclassRemoved is a class removed from the serializable superclasses.
+	SuperclassRemoved ChangeKind = "superclassRemoved"
+	// HierarchyChanged is a class moved up or down the serializable superclasses.
+	HierarchyChanged ChangeKind = "hierarchyChanged"
+)
+
+const scWriteMethod uint8 = 0x01
+const scSerializable uint8 = 0x02
+const scExternalizable uint8 = 0x04
+const scEnum uint8 = 0x10
+
+// CompatibilityReport lists the changes of the classes found in both compared catalogs.
+type CompatibilityReport struct {
+	Compatible bool                  `json:"compatible"`
+	Changes    []CompatibilityChange `json:"changes"`
+}
+
+// CompatibilityChange is a single class change, classified by the compatible and incompatible change rules
+// of the java object serialization specification.
+type CompatibilityChange struct {
+	Class       string     `json:"class"`
+	Field       string     `json:"field,omitempty"`
+	Kind        ChangeKind `json:"kind"`
+	Compatible  bool       `json:"compatible"`
+	Description string     `json:"description"`
+}
+
+// CheckCompatibility compares the classes of an old catalog with the classes of a new catalog,
+// classes found in only one of the catalogs are not reported.
+func CheckCompatibility(oldCatalog, newCatalog *ClassCatalog) *CompatibilityReport {
+	report := &CompatibilityReport{Compatible: true, Changes: []CompatibilityChange{}}
+
+	for _, oldClass := range oldCatalog.Classes {
+		newClass, exists := newCatalog.Class(oldClass.Name)
+		if !exists {
+			continue
+		}
+
+		report.compareClass(oldClass, newClass)
+		report.compareFields(oldClass, newClass)
+		report.compareHierarchy(oldClass.Name, oldCatalog.superclasses(oldClass), newCatalog.superclasses(newClass))
+	}
+
+	return report
+}
+
+// add appends a change to the report.
+func (r *CompatibilityReport) add(class, field string, kind ChangeKind, compatible bool, format string, args ...interface{}) {
+	r.Changes = append(r.Changes, CompatibilityChange{
+		Class:       class,
+		Field:       field,
+		Kind:        kind,
+		Compatible:  compatible,
+		Description: fmt.Sprintf(format, args...),
+	})
+
+	r.Compatible = r.Compatible && compatible
+}
+
+// compareClass reports serialVersionUID and class flags changes.
+func (r *CompatibilityReport) compareClass(oldClass, newClass ClassDescriptor) {
+	if oldClass.SerialVersionUID != newClass.SerialVersionUID {
+		r.add(oldClass.Name, "", SerialVersionUIDChanged, oldClass.Record && newClass.Record,
+			"serialVersionUID changed from %d to %d", oldClass.SerialVersionUID, newClass.SerialVersionUID)
+	}
+
+	if oldWrite, newWrite := oldClass.Flags&scWriteMethod != 0, newClass.Flags&scWriteMethod != 0; oldWrite != newWrite {
+		r.add(oldClass.Name, "", WriteMethodChanged, true, "writeObject method %s", addedOrRemoved(newWrite))
+	}
+
+	if oldKind, newKind := serializationKind(oldClass.Flags), serializationKind(newClass.Flags); oldKind != newKind {
+		r.add(oldClass.Name, "", SerializationKindChanged, false, "changed from %s to %s", oldKind, newKind)
+	}
+
+	if oldEnum, newEnum := oldClass.Flags&scEnum != 0, newClass.Flags&scEnum != 0; oldEnum != newEnum {
+		r.add(oldClass.Name, "", EnumKindChanged, false, "enum type %s", addedOrRemoved(newEnum))
+	}
+}
+
+// compareFields reports removed fields and type changes in the old field order, then added fields in the new order.
+func (r *CompatibilityReport) compareFields(oldClass, newClass ClassDescriptor) {
+	newFields := make(map[string]FieldDescriptor, len(newClass.Fields))
+	for _, f := range newClass.Fields {
+		newFields[f.Name] = f
+	}
+
+	oldFields := make(map[string]bool, len(oldClass.Fields))
+	for _, oldField := range oldClass.Fields {
+		oldFields[oldField.Name] = true
+
+		newField, exists := newFields[oldField.Name]
+		if !exists {
+			r.add(oldClass.Name, oldField.Name, FieldRemoved, false, "field of type %s removed", oldField.Type)
+			continue
+		}
+
+		if oldField.Signature != newField.Signature {
+			r.add(oldClass.Name, oldField.Name, FieldTypeChanged, false,
+				"field type changed from %s to %s", oldField.Type, newField.Type)
+		}
+	}
+
+	for _, newField := range newClass.Fields {
+		if !oldFields[newField.Name] {
+			r.add(oldClass.Name, newField.Name, FieldAdded, true, "field of type %s added", newField.Type)
+		}
+	}
+}
+
+// compareHierarchy reports superclasses added, removed or reordered between two superclass chains.
+func (r *CompatibilityReport) compareHierarchy(class string, oldSupers, newSupers []string) {
+	oldIndexes := make(map[string]int, len(oldSupers))
+	for i, name := range oldSupers {
+		oldIndexes[name] = i
+	}
+
+	newIndexes := make(map[string]int, len(newSupers))
+	for i, name := range newSupers {
+		newIndexes[name] = i
+	}
+
+	for _, name := range oldSupers {
+		if _, exists := newIndexes[name]; !exists {
+			r.add(class, "", SuperclassRemoved, true, "superclass %s removed", name)
+		}
+	}
+
+	last := -1
+	for _, name := range newSupers {
+		i, exists := oldIndexes[name]
+		if !exists {
+			r.add(class, "", SuperclassAdded, true, "superclass %s added", name)
+			continue
+		}
+
+		if i < last {
+			r.add(class, "", HierarchyChanged, false, "superclass %s moved in the hierarchy", name)
+		}
+
+		last = i
+	}
+}
+
+// superclasses returns the names of the serializable superclasses of a class, nearest first.
+func (c *ClassCatalog) superclasses(cd ClassDescriptor) []string {
+	var supers []string
+	seen := map[string]bool{cd.Name: true}
+
+	for name := cd.Super; name != "" && !seen[name]; {
+		seen[name] = true
+		supers = append(supers, name)
+
+		super, exists := c.Class(name)
+		if !exists {
+			break
+		}
+
+		name = super.Super
+	}
+
+	return supers
+}
+
+// serializationKind names the serialization mechanism selected by class flags.
+func serializationKind(flags uint8) string {
+	switch {
+	case flags&scExternalizable != 0:
+		return "Externalizable"
+	case flags&scSerializable != 0:
+		return "Serializable"
+	}
+
+	return "not serializable"
+}
+
+// addedOrRemoved describes whether something exists in the new class.
+func addedOrRemoved(exists bool) string {
+	if exists {
+		return "added"
+	}
+
+	return "removed"
+}
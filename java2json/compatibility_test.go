@@ -0,0 +1,70 @@
+package java2json
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	oldCatalog := &ClassCatalog{Classes: []ClassDescriptor{
+		{Name: "com.foo.Order", SerialVersionUID: 1, Flags: 0x02, Super: "com.foo.Base", Fields: []FieldDescriptor{
+			{Name: "id", Type: "int", Signature: "I"},
+			{Name: "note", Type: "java.lang.String", Signature: "Ljava/lang/String;"},
+		}},
+		{Name: "com.foo.Base", SerialVersionUID: 1, Flags: 0x02, Fields: []FieldDescriptor{}},
+		{Name: "com.foo.Point", SerialVersionUID: 0, Flags: 0x02, Record: true, Fields: []FieldDescriptor{}},
+		{Name: "com.foo.Removed", SerialVersionUID: 1, Flags: 0x02, Fields: []FieldDescriptor{}},
+	}}
+
+	newCatalog := &ClassCatalog{Classes: []ClassDescriptor{
+		{Name: "com.foo.Order", SerialVersionUID: 2, Flags: 0x03, Super: "com.foo.Entity", Fields: []FieldDescriptor{
+			{Name: "id", Type: "long", Signature: "J"},
+			{Name: "total", Type: "double", Signature: "D"},
+		}},
+		{Name: "com.foo.Entity", SerialVersionUID: 1, Flags: 0x02, Super: "com.foo.Base", Fields: []FieldDescriptor{}},
+		{Name: "com.foo.Base", SerialVersionUID: 1, Flags: 0x06, Fields: []FieldDescriptor{}},
+		{Name: "com.foo.Point", SerialVersionUID: 5, Flags: 0x02, Record: true, Fields: []FieldDescriptor{}},
+	}}
+
+	expected := `{"compatible":false,"changes":[` +
+		`{"class":"com.foo.Order","kind":"serialVersionUIDChanged","compatible":false,"description":"serialVersionUID changed from 1 to 2"},` +
+		`{"class":"com.foo.Order","kind":"writeMethodChanged","compatible":true,"description":"writeObject method added"},` +
+		`{"class":"com.foo.Order","field":"id","kind":"fieldTypeChanged","compatible":false,"description":"field type changed from int to long"},` +
+		`{"class":"com.foo.Order","field":"note","kind":"fieldRemoved","compatible":false,"description":"field of type java.lang.String removed"},` +
+		`{"class":"com.foo.Order","field":"total","kind":"fieldAdded","compatible":true,"description":"field of type double added"},` +
+		`{"class":"com.foo.Order","kind":"superclassAdded","compatible":true,"description":"superclass com.foo.Entity added"},` +
+		`{"class":"com.foo.Base","kind":"serializationKindChanged","compatible":false,"description":"changed from Serializable to Externalizable"},` +
+		`{"class":"com.foo.Point","kind":"serialVersionUIDChanged","compatible":true,"description":"serialVersionUID changed from 0 to 5"}]}`
+
+	b, err := json.Marshal(CheckCompatibility(oldCatalog, newCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(b) != expected {
+		t.Errorf("unexpected report\nwant: %s\ngot:  %s", expected, b)
+	}
+}
+
+func TestCheckCompatibilityHierarchyMoved(t *testing.T) {
+	oldCatalog := &ClassCatalog{Classes: []ClassDescriptor{
+		{Name: "C", Flags: 0x02, Super: "B", Fields: []FieldDescriptor{}},
+		{Name: "B", Flags: 0x02, Super: "A", Fields: []FieldDescriptor{}},
+		{Name: "A", Flags: 0x02, Fields: []FieldDescriptor{}},
+	}}
+
+	newCatalog := &ClassCatalog{Classes: []ClassDescriptor{
+		{Name: "C", Flags: 0x02, Super: "A", Fields: []FieldDescriptor{}},
+		{Name: "A", Flags: 0x02, Super: "B", Fields: []FieldDescriptor{}},
+		{Name: "B", Flags: 0x02, Fields: []FieldDescriptor{}},
+	}}
+
+	report := CheckCompatibility(oldCatalog, newCatalog)
+	if report.Compatible {
+		t.Fatalf("expected incompatible report, got %+v", report)
+	}
+
+	if len(report.Changes) != 3 || report.Changes[0].Kind != HierarchyChanged || report.Changes[0].Class != "C" {
+		t.Errorf("unexpected changes %+v", report.Changes)
+	}
+}
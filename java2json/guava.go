@@ -0,0 +1,148 @@
+package java2json
+
+import "github.com/pkg/errors"
+
+// guavaHandlerPack unwraps Guava immutable collections and multimaps.
+var guavaHandlerPack = handlerPack{
+	postProcs: map[string]postProc{
+		"com.google.common.collect.ImmutableList$SerializedForm":      fieldPostProc("elements"),
+		"com.google.common.collect.ImmutableSet$SerializedForm":       fieldPostProc("elements"),
+		"com.google.common.collect.ImmutableSortedSet$SerializedForm": fieldPostProc("elements"),
+		"com.google.common.collect.ImmutableMap$SerializedForm":       immutableMapPostProc,
+		"com.google.common.collect.ArrayListMultimap":                 multimapPostProc,
+		"com.google.common.collect.HashMultimap":                      multimapPostProc,
+		"com.google.common.collect.ImmutableListMultimap":             multimapPostProc,
+		"com.google.common.collect.ImmutableSetMultimap":              immutableSetMultimapPostProc,
+		"com.google.common.collect.LinkedHashMultimap":                linkedHashMultimapPostProc,
+		"com.google.common.collect.LinkedListMultimap":                linkedListMultimapPostProc,
+	},
+}
+
+// immutableMapPostProc populates the object value with a map of the "keys" and "values" fields,
+// it also applies to the sorted map and bimap forms extending the map form.
+func immutableMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	keys, isSlice := fields["keys"].([]interface{})
+	if !isSlice {
+		return nil, errors.New("unexpected keys value")
+	}
+
+	values, isSlice := fields["values"].([]interface{})
+	if !isSlice || len(values) != len(keys) {
+		return nil, errors.New("unexpected values value")
+	}
+
+	m := make(javaMap, len(keys))
+	for i := range keys {
+		m[i] = mapEntry{key: keys[i], value: values[i]}
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
+
+// multimapPostProc populates the object value with a map of lists,
+// the number of keys is followed by each key, its number of values and the values.
+func multimapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < 0 || size*2+1 > len(data) {
+		return nil, errors.Errorf("invalid multimap size %d", size)
+	}
+
+	m := make(javaMap, size)
+	pos := 1
+
+	for i := range m {
+		if pos >= len(data) {
+			return nil, errors.Errorf("incorrect number of keys: want %d got %d", size, i)
+		}
+
+		count, err := postProcSize(data[pos+1:], 0)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error reading value count of key %d", i)
+		}
+
+		if count < 0 || pos+2+count > len(data) {
+			return nil, errors.Errorf("incorrect number of values of key %d: want %d got %d", i, count, len(data)-pos-2)
+		}
+
+		m[i] = mapEntry{key: data[pos], value: data[pos+2 : pos+2+count]}
+		pos += 2 + count
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
+
+// immutableSetMultimapPostProc populates the object value with a map of lists,
+// the value comparator precedes the multimap keys and values.
+func immutableSetMultimapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if len(data) < 1 {
+		return nil, errors.New("invalid data: comparator required")
+	}
+
+	return multimapPostProc(fields, data[1:])
+}
+
+// linkedHashMultimapPostProc populates the object value with a map of lists,
+// the keys in their iteration order are followed by every key/value entry.
+func linkedHashMultimapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < 0 {
+		return nil, errors.Errorf("invalid multimap size %d", size)
+	}
+
+	if size+1 > len(data) {
+		return nil, errors.Errorf("incorrect number of keys: want %d got %d", size, len(data)-1)
+	}
+
+	m := make(javaMap, size)
+	for i := range m {
+		m[i] = mapEntry{key: data[i+1], value: []interface{}{}}
+	}
+
+	return multimapEntries(fields, m, data[size+1:])
+}
+
+// linkedListMultimapPostProc populates the object value with a map of lists built from every key/value entry.
+func linkedListMultimapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	return multimapEntries(fields, javaMap{}, data)
+}
+
+// multimapEntries appends the values of the sized key/value entries to the lists of m,
+// adding keys which are not in m yet.
+func multimapEntries(fields map[string]interface{}, m javaMap, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < 0 || size*2+1 > len(data) {
+		return nil, errors.Errorf("incorrect number of entries: want %d got %d", size, (len(data)-1)/2)
+	}
+
+	for i := 0; i < size; i++ {
+		key, value := data[2*i+1], data[2*i+2]
+
+		idx := 0
+		for idx < len(m) && !sameValue(m[idx].key, key) {
+			idx++
+		}
+
+		if idx == len(m) {
+			m = append(m, mapEntry{key: key, value: []interface{}{}})
+		}
+
+		m[idx].value = append(m[idx].value.([]interface{}), value)
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
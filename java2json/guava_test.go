@@ -0,0 +1,56 @@
+package java2json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestGuavaImmutableList(t *testing.T) {
+	input := "rO0ABXNyADZjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkltbXV0YWJsZUxpc3QkU2VyaWFsaXplZEZvcm0AAAAAAAAAAAIAAUwACGVsZW1lbnRzdAATW0xqYXZhL2xhbmcvT2JqZWN0O3hwdXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAAAnQAAWF0AAFi"
+	expected := `["a","b"]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(GuavaPack)
+	})
+}
+
+func TestGuavaImmutableSortedMap(t *testing.T) {
+	input := "rO0ABXNyADtjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkltbXV0YWJsZVNvcnRlZE1hcCRTZXJpYWxpemVkRm9ybQAAAAAAAAAAAgABTAAKY29tcGFyYXRvcnQAFkxqYXZhL3V0aWwvQ29tcGFyYXRvcjt4cgA1Y29tLmdvb2dsZS5jb21tb24uY29sbGVjdC5JbW11dGFibGVNYXAkU2VyaWFsaXplZEZvcm0AAAAAAAAAAAIAAkwABGtleXN0ABJMamF2YS9sYW5nL09iamVjdDtMAAZ2YWx1ZXNxAH4AA3hwdXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAAAnQAAWF0AAFidXEAfgAFAAAAAnNyABFqYXZhLmxhbmcuSW50ZWdlchLioKT3gYc4AgABSQAFdmFsdWV4cgAQamF2YS5sYW5nLk51bWJlcoaslR0LlOCLAgAAeHAAAAABc3EAfgAKAAAAAnA="
+	expected := `{"a":1,"b":2}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(GuavaPack)
+	})
+}
+
+func TestGuavaArrayListMultimap(t *testing.T) {
+	input := "rO0ABXNyACtjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkFycmF5TGlzdE11bHRpbWFwAAAAAAAAAAADAAB4cHcEAAAAAnQAAWF3BAAAAAJ0AAF4dAABeXQAAWJ3BAAAAAF0AAF6eA=="
+	expected := `{"a":["x","y"],"b":["z"]}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(GuavaPack)
+	})
+}
+
+func TestGuavaLinkedHashMultimap(t *testing.T) {
+	input := "rO0ABXNyACxjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkxpbmtlZEhhc2hNdWx0aW1hcAAAAAAAAAABAwAAeHB3BAAAAAJ0AAFhdAABYncEAAAAA3EAfgADdAABeHEAfgACdAABeXEAfgADdAABeng="
+	expected := `{"a":["y"],"b":["x","z"]}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(GuavaPack)
+	})
+}
+
+func TestGuavaMultimapNegativeSize(t *testing.T) {
+	inputs := []string{
+		"rO0ABXNyACtjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkFycmF5TGlzdE11bHRpbWFwAAAAAAAAAAADAAB4cHcE/////3g=",
+		"rO0ABXNyACtjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkFycmF5TGlzdE11bHRpbWFwAAAAAAAAAAADAAB4cHcEAAAAAXQAAWF3BP////94",
+		"rO0ABXNyACxjb20uZ29vZ2xlLmNvbW1vbi5jb2xsZWN0LkxpbmtlZEhhc2hNdWx0aW1hcAAAAAAAAAABAwAAeHB3BP////94",
+	}
+
+	for _, input := range inputs {
+		buf, _ := base64.StdEncoding.DecodeString(input)
+		jop := NewJavaObjectParser(bytes.NewReader(buf))
+		jop.EnableHandlerPacks(GuavaPack)
+		if _, err := jop.ParseJavaObject(); err == nil {
+			t.Errorf("expected invalid multimap size error for %s", input)
+		}
+	}
+}
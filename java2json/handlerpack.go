@@ -0,0 +1,68 @@
+package java2json
+
+// HandlerPack identifies an optional set of handlers for the classes of a third party library,
+// pack handlers match classes by name regardless of their serialVersionUID.
+type HandlerPack int
+
+const (
+	// HibernatePack unwraps Hibernate persistent collections and serializable proxies.
+	HibernatePack HandlerPack = iota + 1
+	// SpringSecurityPack renders Spring Security contexts, authentication tokens, users and granted authorities,
+	// and Spring Session sessions.
+	SpringSecurityPack
+	// GuavaPack unwraps Guava immutable collections and multimaps.
+	GuavaPack
+	// JodaPack renders Joda-Time instants, local dates and times, durations and periods,
+	// date times in a time zone unknown to the time zone database fail to parse.
+	JodaPack
+	// ScalaPack unwraps Scala collection serialization proxies, options and tuples.
+	ScalaPack
+	// KotlinPack unwraps Kotlin collection builders and empty collections.
+	KotlinPack
+)
+
+// handlerPack contains the handlers of a HandlerPack keyed by class name.
+type handlerPack struct {
+	postProcs       map[string]postProc
+	objectPostProcs map[string]objectPostProc
+}
+
+// knownHandlerPacks maps handler pack identifiers to their handlers.
+var knownHandlerPacks = map[HandlerPack]handlerPack{
+	HibernatePack:      hibernateHandlerPack,
+	SpringSecurityPack: springSecurityHandlerPack,
+	GuavaPack:          guavaHandlerPack,
+	JodaPack:           jodaHandlerPack,
+	ScalaPack:          scalaHandlerPack,
+	KotlinPack:         kotlinHandlerPack,
+}
+
+// findPostProc returns the postProc of a class, known signatures take precedence over enabled handler packs.
+func (jop *JavaObjectParser) findPostProc(cls *clazz) (postProc, bool) {
+	if postproc, exists := knownPostProcs[cls.name+"@"+cls.serialVersionUID]; exists {
+		return postproc, true
+	}
+
+	for _, pack := range jop.handlerPacks {
+		if postproc, exists := pack.postProcs[cls.name]; exists {
+			return postproc, true
+		}
+	}
+
+	return nil, false
+}
+
+// findObjectPostProc returns the objectPostProc of a class, known signatures take precedence over enabled handler packs.
+func (jop *JavaObjectParser) findObjectPostProc(cls *clazz) (objectPostProc, bool) {
+	if postproc, exists := knownObjectPostProcs[cls.name+"@"+cls.serialVersionUID]; exists {
+		return postproc, true
+	}
+
+	for _, pack := range jop.handlerPacks {
+		if postproc, exists := pack.objectPostProcs[cls.name]; exists {
+			return postproc, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,48 @@
+package java2json
+
+// hibernateHandlerPack unwraps Hibernate persistent collections and serializable proxies.
+var hibernateHandlerPack = handlerPack{
+	objectPostProcs: map[string]objectPostProc{
+		"org.hibernate.collection.internal.AbstractPersistentCollection": persistentCollectionPostProc,
+		"org.hibernate.collection.spi.AbstractPersistentCollection":      persistentCollectionPostProc,
+		"org.hibernate.proxy.AbstractSerializableProxy":                  serializableProxyPostProc,
+	},
+}
+
+// persistentCollectionFields includes the fields holding the underlying collection of each persistent collection.
+var persistentCollectionFields = []string{"bag", "list", "set", "map", "values", "array"}
+
+// persistentCollectionPostProc populates the object value with the underlying collection,
+// or with an uninitialized marker including the collection owner and role when it was not loaded.
+func persistentCollectionPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	if initialized, _ := obj["initialized"].(bool); !initialized {
+		obj[objectValueField] = map[string]interface{}{
+			"uninitialized": true,
+			"owner":         obj["owner"],
+			"role":          obj["role"],
+			"key":           obj["key"],
+		}
+
+		return obj, nil
+	}
+
+	for _, name := range persistentCollectionFields {
+		if collection, exists := obj[name]; exists {
+			obj[objectValueField] = collection
+			break
+		}
+	}
+
+	return obj, nil
+}
+
+// serializableProxyPostProc populates the object value with an uninitialized marker including the entity name and id.
+func serializableProxyPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	obj[objectValueField] = map[string]interface{}{
+		"uninitialized": true,
+		"entityName":    obj["entityName"],
+		"id":            obj["id"],
+	}
+
+	return obj, nil
+}
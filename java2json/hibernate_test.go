@@ -0,0 +1,19 @@
+package java2json
+
+import "testing"
+
+func TestHibernatePersistentCollections(t *testing.T) {
+	input := "rO0ABXNyABFjb20uZXhhbXBsZS5PcmRlcgAAAAAAAAABAgACTAAFaXRlbXN0ABBMamF2YS91dGlsL0xpc3Q7TAAEdGFnc3QAD0xqYXZhL3V0aWwvU2V0O3hwc3IAL29yZy5oaWJlcm5hdGUuY29sbGVjdGlvbi5pbnRlcm5hbC5QZXJzaXN0ZW50QmFnAAAAAAAAAcgCAAFMAANiYWdxAH4AAXhyAD5vcmcuaGliZXJuYXRlLmNvbGxlY3Rpb24uaW50ZXJuYWwuQWJzdHJhY3RQZXJzaXN0ZW50Q29sbGVjdGlvbgAAAAAAAAB7AgALWgAbYWxsb3dMb2FkT3V0c2lkZVRyYW5zYWN0aW9uSQAKY2FjaGVkU2l6ZVoABWRpcnR5WgAOZWxlbWVudFJlbW92ZWRaAAtpbml0aWFsaXplZFoADWlzVGVtcFNlc3Npb25MAANrZXl0ABZMamF2YS9pby9TZXJpYWxpemFibGU7TAAFb3duZXJ0ABJMamF2YS9sYW5nL09iamVjdDtMAARyb2xldAASTGphdmEvbGFuZy9TdHJpbmc7TAASc2Vzc2lvbkZhY3RvcnlVdWlkcQB+AAhMAA5zdG9yZWRTbmFwc2hvdHEAfgAGeHAA/////wAAAQBzcgAOamF2YS5sYW5nLkxvbmc7i+SQzI8j3wIAAUoABXZhbHVleHIAEGphdmEubGFuZy5OdW1iZXKGrJUdC5TgiwIAAHhwAAAAAAAAACpwdAAXY29tLmV4YW1wbGUuT3JkZXIuaXRlbXN0AAZ1dWlkLTFzcgATamF2YS51dGlsLkFycmF5TGlzdHiB0h2Zx2GdAwABSQAEc2l6ZXhwAAAAAncEAAAAAnQAAmkxdAACaTJ4c3EAfgAPAAAAAncEAAAAAnEAfgARcQB+ABJ4c3IAL29yZy5oaWJlcm5hdGUuY29sbGVjdGlvbi5pbnRlcm5hbC5QZXJzaXN0ZW50U2V0AAAAAAAAAxUCAAFMAANzZXRxAH4AAnhxAH4ABQD/////AAAAAHNxAH4ACgAAAAAAAAAqcHQAFmNvbS5leGFtcGxlLk9yZGVyLnRhZ3NxAH4ADnBw"
+	expected := `{"items":["i1","i2"],"tags":{"key":42,"owner":null,"role":"com.example.Order.tags","uninitialized":true}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(HibernatePack)
+	})
+}
+
+func TestHibernateProxy(t *testing.T) {
+	input := "rO0ABXNyADRvcmcuaGliZXJuYXRlLnByb3h5LnBvam8uYnl0ZWJ1ZGR5LlNlcmlhbGl6YWJsZVByb3h5AAAAAAAAAAICAAJMABppZGVudGlmaWVyR2V0dGVyTWV0aG9kTmFtZXQAEkxqYXZhL2xhbmcvU3RyaW5nO0wAD3BlcnNpc3RlbnRDbGFzc3QAEUxqYXZhL2xhbmcvQ2xhc3M7eHIALW9yZy5oaWJlcm5hdGUucHJveHkuQWJzdHJhY3RTZXJpYWxpemFibGVQcm94eQAAAAAAAAABAgAFWgAbYWxsb3dMb2FkT3V0c2lkZVRyYW5zYWN0aW9uTAAKZW50aXR5TmFtZXEAfgABTAACaWR0ABZMamF2YS9pby9TZXJpYWxpemFibGU7TAAIcmVhZE9ubHl0ABNMamF2YS9sYW5nL0Jvb2xlYW47TAASc2Vzc2lvbkZhY3RvcnlVdWlkcQB+AAF4cAB0ABRjb20uZXhhbXBsZS5DdXN0b21lcnNyAA5qYXZhLmxhbmcuTG9uZzuL5JDMjyPfAgABSgAFdmFsdWV4cgAQamF2YS5sYW5nLk51bWJlcoaslR0LlOCLAgAAeHAAAAAAAAAAB3BwdAAFZ2V0SWR2cgAUY29tLmV4YW1wbGUuQ3VzdG9tZXIAAAAAAAAAAQIAAHhw"
+	expected := `{"entityName":"com.example.Customer","id":7,"uninitialized":true}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(HibernatePack)
+	})
+}
@@ -7,8 +7,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf16"
 
 	"github.com/pkg/errors"
 )
@@ -28,6 +32,7 @@ func NewJavaObjectParser(rd io.Reader) *JavaObjectParser {
 		rd:                  buf,
 		maxDataBlockSize:    buf.Size(),
 		cycleReferenceValue: defaultCycleReferenceValue,
+		inProgress:          make(map[int]bool),
 	}
 
 	return jop
@@ -45,6 +50,81 @@ func (jop *JavaObjectParser) SetCycleReferenceValue(cycleReferenceValue string)
 	jop.cycleReferenceValue = cycleReferenceValue
 }
 
+// SetOrderedMaps set whether java maps are returned as *OrderedMap keeping the stream order,
+// by default they are returned as map[string]interface{}.
+func (jop *JavaObjectParser) SetOrderedMaps(orderedMaps bool) {
+	jop.orderedMaps = orderedMaps
+}
+
+// SetMapKeyPolicy set how java map keys are converted,
+// by default they are formatted with fmt.Sprint.
+func (jop *JavaObjectParser) SetMapKeyPolicy(mapKeyPolicy MapKeyPolicy) {
+	jop.mapKeyPolicy = mapKeyPolicy
+}
+
+// SetByteArrayFormat set how byte arrays are returned,
+// by default they are returned as a list of numbers.
+func (jop *JavaObjectParser) SetByteArrayFormat(byteArrayFormat ByteArrayFormat) {
+	jop.byteArrayFormat = byteArrayFormat
+}
+
+// SetLongFormat set how long values are returned,
+// by default they are returned as numbers.
+func (jop *JavaObjectParser) SetLongFormat(longFormat LongFormat) {
+	jop.longFormat = longFormat
+}
+
+// SetNonFiniteFormat set how NaN and infinite float and double values are returned,
+// by default they are returned as numbers which cannot be marshalled by encoding/json.
+func (jop *JavaObjectParser) SetNonFiniteFormat(nonFiniteFormat NonFiniteFormat) {
+	jop.nonFiniteFormat = nonFiniteFormat
+}
+
+// SetEnumFormat set how enum constants are returned,
+// by default they are returned as their name.
+func (jop *JavaObjectParser) SetEnumFormat(enumFormat EnumFormat) {
+	jop.enumFormat = enumFormat
+}
+
+// SetClassFormat set how class values are returned,
+// by default they are returned as their name.
+func (jop *JavaObjectParser) SetClassFormat(classFormat ClassFormat) {
+	jop.classFormat = classFormat
+}
+
+// SetReferenceMode set whether shared and cyclic objects are returned once with an "$id"
+// and referenced elsewhere as {"$ref": id}, by default shared objects are repeated
+// and cyclic references are replaced with the cycle reference value.
+func (jop *JavaObjectParser) SetReferenceMode(referenceMode bool) {
+	jop.referenceMode = referenceMode
+}
+
+// SetRecordMarker set whether record objects include a "@record" field with their class name,
+// by default records are returned like other objects.
+func (jop *JavaObjectParser) SetRecordMarker(recordMarker bool) {
+	jop.recordMarker = recordMarker
+}
+
+// SetRecordComponents set the components of the local version of a record class as names mapped to type
+// signatures, e.g. {"x": "I", "label": "Ljava/lang/String;"}. Components missing from the stream are filled with
+// Java default values like the JDK does when it calls the canonical constructor.
+func (jop *JavaObjectParser) SetRecordComponents(className string, components map[string]string) {
+	if jop.recordComponents == nil {
+		jop.recordComponents = make(map[string]map[string]string)
+	}
+
+	jop.recordComponents[className] = components
+}
+
+// EnableHandlerPacks enables optional handler packs for third party library classes.
+func (jop *JavaObjectParser) EnableHandlerPacks(packs ...HandlerPack) {
+	for _, pack := range packs {
+		if hp, exists := knownHandlerPacks[pack]; exists {
+			jop.handlerPacks = append(jop.handlerPacks, hp)
+		}
+	}
+}
+
 // ParseSerializedObject parses a serialized java object from stream.
 func (jop *JavaObjectParser) ParseJavaObject() (content interface{}, err error) {
 	if err = jop.magic(); err != nil {
@@ -65,8 +145,16 @@ func (jop *JavaObjectParser) ParseJavaObject() (content interface{}, err error)
 
 	if !jop.end() {
 		err = errors.New("object already parsed but there is more data")
+		return
+	}
+
+	if jop.referenceMode {
+		jop.references = make(map[identity]int)
+		jop.referenceIDs = make(map[identity]string)
+		jop.countReferences(content)
 	}
 
+	content, err = jop.output(content)
 	return
 }
 
@@ -87,12 +175,33 @@ const scSerializableWithoutWriteMethod uint8 = 0x02
 const scSerializableWithWriteMethod uint8 = 0x03
 const scExternalizeWithBlockData uint8 = 0x04
 const scExternalizeWithoutBlockData uint8 = 0x0c
+const serDurationType byte = 1
+const serInstantType byte = 2
 const serLocalDateType byte = 3
 const serLocalTimeType byte = 4
 const serLocalDateTimeType byte = 5
+const serSecondsNanosBlockSize int = 13
 const serLocalDateBlockSize int = 7
 const serLocalTimeBlockSize int = 7
 const serLocalDateTimeBlockSize int = 14
+const inetAddressFamilyIPv4 int32 = 1
+const collSerTagMask int32 = 0xff
+const collSerList int32 = 1
+const collSerSet int32 = 2
+const collSerMap int32 = 3
+const collSerListNulls int32 = 4
+const sqlDateLayout string = "2006-01-02"
+const sqlTimeLayout string = "15:04:05.999999999"
+const stackTraceNativeMethod int32 = -2
+const stackTraceBuiltinClassLoader int8 = 0x1
+const stackTraceJDKNonUpgradeableModule int8 = 0x2
+const recordClassName string = "java.lang.Record"
+const recordField string = "@record"
+const enumClassName string = "java.lang.Enum"
+const undeterminedLanguageTag string = "und"
+const privateUseSingleton string = "x"
+const privateUseVariantPrefix string = "lvariant"
+const bitsPerLong int = 64
 
 // typeNames includes all known type names.
 var typeNames = []string{
@@ -129,26 +238,96 @@ type postProc func(map[string]interface{}, []interface{}) (map[string]interface{
 
 // knownPostProcs maps serialized object signatures to PostProc implementations.
 var knownPostProcs = map[string]postProc{
-	"java.lang.Byte@9c4e6084ee50f51c":                            primObjectPostProc,
-	"java.lang.Character@348b47d96b1a2678":                       primObjectPostProc,
-	"java.lang.Double@80b3c24a296bfb04":                          primObjectPostProc,
-	"java.lang.Float@daedc9a2db3cf0ec":                           primObjectPostProc,
-	"java.lang.Integer@12e2a0a4f7818738":                         primObjectPostProc,
-	"java.lang.Long@3b8be490cc8f23df":                            primObjectPostProc,
-	"java.lang.Short@684d37133460da52":                           primObjectPostProc,
-	"java.lang.Boolean@cd207280d59cfaee":                         primObjectPostProc,
-	"java.util.ArrayList@7881d21d99c7619d":                       listPostProc,
-	"java.util.ArrayDeque@207cda2e240da08b":                      listPostProc,
-	"java.util.Hashtable@13bb0f25214ae4b8":                       mapPostProc,
-	"java.util.HashMap@0507dac1c31660d1":                         mapPostProc,
-	"java.util.EnumMap@065d7df7be907ca1":                         enumMapPostProc,
-	"java.util.HashSet@ba44859596b8b734":                         hashSetPostProc,
-	"java.util.Date@686a81014b597419":                            datePostProc,
-	"java.util.Calendar@e6ea4d1ec8dc5b8e":                        calendarPostProc,
-	"java.util.Arrays$ArrayList@d9a43cbecd8806d2":                arraysArrayListPostProc,
-	"java.util.concurrent.CopyOnWriteArrayList@785d9fd546ab90c3": listPostProc,
-	"java.util.CollSer@578eabb63a1ba811":                         listPostProc,
-	"java.time.Ser@955d84ba1b2248b2":                             serPostProc,
+	"java.lang.Byte@9c4e6084ee50f51c":                                                   primObjectPostProc,
+	"java.lang.Character@348b47d96b1a2678":                                              primObjectPostProc,
+	"java.lang.Double@80b3c24a296bfb04":                                                 primObjectPostProc,
+	"java.lang.Float@daedc9a2db3cf0ec":                                                  primObjectPostProc,
+	"java.lang.Integer@12e2a0a4f7818738":                                                primObjectPostProc,
+	"java.lang.Long@3b8be490cc8f23df":                                                   primObjectPostProc,
+	"java.lang.Short@684d37133460da52":                                                  primObjectPostProc,
+	"java.lang.Boolean@cd207280d59cfaee":                                                primObjectPostProc,
+	"java.util.ArrayList@7881d21d99c7619d":                                              listPostProc,
+	"java.util.ArrayDeque@207cda2e240da08b":                                             listPostProc,
+	"java.util.Hashtable@13bb0f25214ae4b8":                                              mapPostProc,
+	"java.util.HashMap@0507dac1c31660d1":                                                mapPostProc,
+	"java.util.EnumMap@065d7df7be907ca1":                                                enumMapPostProc,
+	"java.util.HashSet@ba44859596b8b734":                                                hashSetPostProc,
+	"java.util.Date@686a81014b597419":                                                   datePostProc,
+	"java.util.Calendar@e6ea4d1ec8dc5b8e":                                               calendarPostProc,
+	"java.util.Arrays$ArrayList@d9a43cbecd8806d2":                                       arraysArrayListPostProc,
+	"java.util.concurrent.CopyOnWriteArrayList@785d9fd546ab90c3":                        listPostProc,
+	"java.util.CollSer@578eabb63a1ba811":                                                collSerPostProc,
+	"java.time.Ser@955d84ba1b2248b2":                                                    serPostProc,
+	"java.util.UUID@bc9903f7986d852f":                                                   uuidPostProc,
+	"java.net.URI@ac01782e439e49ab":                                                     uriPostProc,
+	"java.net.URL@962537361afce472":                                                     urlPostProc,
+	"java.net.InetAddress@2d9b57af9fe3ebdb":                                             inetAddressPostProc,
+	"java.net.Inet6Address@5f7c2081522c8021":                                            inet6AddressPostProc,
+	"java.net.InetSocketAddress@467194616ff9aa45":                                       inetSocketAddressPostProc,
+	"java.util.TreeMap@0cc1f63e2d256ae6":                                                sizedMapPostProc,
+	"java.util.TreeSet@dd98509395ed875b":                                                treeSetPostProc,
+	"java.util.LinkedList@0c29535d4a608822":                                             listPostProc,
+	"java.util.PriorityQueue@94da30b4fb3f82b1":                                          priorityQueuePostProc,
+	"java.util.Vector@d9977d5b803baf01":                                                 vectorPostProc,
+	"java.util.IdentityHashMap@71a2650133f2e980":                                        sizedMapPostProc,
+	"java.util.concurrent.ConcurrentHashMap@6499de129d87293d":                           nullTerminatedMapPostProc,
+	"java.util.concurrent.ConcurrentSkipListMap@884675ae061146a7":                       nullTerminatedMapPostProc,
+	"java.util.concurrent.ConcurrentSkipListSet@dd985079bdcff15b":                       setFromMapPostProc,
+	"java.util.concurrent.LinkedBlockingQueue@a0304ca040e581f6":                         nullTerminatedListPostProc,
+	"java.util.concurrent.ArrayBlockingQueue@f4a631b41e106f86":                          arrayBlockingQueuePostProc,
+	"java.util.EnumSet$SerializationProxy@0507d3db7654cad1":                             fieldPostProc("elements"),
+	"java.util.Collections$UnmodifiableCollection@19420080cb5ef71e":                     fieldPostProc("c"),
+	"java.util.Collections$UnmodifiableMap@f1a5a8fe74f50742":                            fieldPostProc("m"),
+	"java.util.Collections$SynchronizedCollection@2a61f84d099c99b5":                     fieldPostProc("c"),
+	"java.util.Collections$SynchronizedMap@1b73f9094b4b397b":                            fieldPostProc("m"),
+	"java.util.Collections$CheckedCollection@15e96dfd18e6cc6f":                          fieldPostProc("c"),
+	"java.util.Collections$CheckedMap@4fb2bcdf0d186368":                                 fieldPostProc("m"),
+	"java.util.Collections$SingletonList@2aef29103ca79b97":                              singletonPostProc,
+	"java.util.Collections$SingletonSet@2c52419829c0b1bf":                               singletonPostProc,
+	"java.util.Collections$SingletonMap@9f230991717f6b91":                               singletonMapPostProc,
+	"java.util.Collections$EmptyList@7ab817b43ca79ede":                                  emptyListPostProc,
+	"java.util.Collections$EmptySet@15f5721db403cb28":                                   emptyListPostProc,
+	"java.util.Collections$EmptyMap@593614855adce7d0":                                   emptyMapPostProc,
+	"java.util.Collections$SetFromMap@2210b25045f21fc4":                                 setFromMapPostProc,
+	"java.lang.StackTraceElement@6109c59a2636dd85":                                      stackTraceElementPostProc,
+	"java.lang.StringBuilder@3cd5fb145a4c6acb":                                          stringBuilderPostProc,
+	"java.lang.StringBuffer@2f0707d9eac8ead3":                                           stringBufferPostProc,
+	"java.lang.invoke.SerializedLambda@6f61d0942c293685":                                serializedLambdaPostProc,
+	"java.util.Locale@7ef811609c30f9ec":                                                 localePostProc,
+	"java.util.Currency@fdcd934a5911a91f":                                               fieldPostProc("currencyCode"),
+	"java.util.regex.Pattern@4667d56b6e49020d":                                          patternPostProc,
+	"java.util.BitSet@6efd887e3934ab21":                                                 bitSetPostProc,
+	"java.util.concurrent.atomic.AtomicInteger@563f5ecc8c6c168a":                        primObjectPostProc,
+	"java.util.concurrent.atomic.AtomicLong@1ac0fab477001718":                           primObjectPostProc,
+	"java.util.concurrent.atomic.AtomicBoolean@4098b70a4f3ffc33":                        atomicBooleanPostProc,
+	"java.util.concurrent.atomic.AtomicReference@e65771d4557854c6":                      primObjectPostProc,
+	"java.util.concurrent.atomic.AtomicIntegerArray@27b857513300bd8b":                   fieldPostProc("array"),
+	"java.util.concurrent.atomic.AtomicLongArray@dff6ce0a62e2bff8":                      fieldPostProc("array"),
+	"java.util.concurrent.atomic.LongAdder$SerializationProxy@6499de12a37d0a3d":         primObjectPostProc,
+	"java.util.concurrent.atomic.DoubleAdder$SerializationProxy@6499de12a37d0a3d":       primObjectPostProc,
+	"java.util.concurrent.atomic.LongAccumulator$SerializationProxy@6499de12a37d0a3d":   primObjectPostProc,
+	"java.util.concurrent.atomic.DoubleAccumulator$SerializationProxy@6499de12a37d0a3d": primObjectPostProc,
+}
+
+// legacyLanguageCodes maps the obsolete ISO 639 language codes kept by old locales to their current codes.
+var legacyLanguageCodes = map[string]string{
+	"iw": "he",
+	"ji": "yi",
+	"in": "id",
+}
+
+// objectPostProc handlers are used to format deserialized objects using the fields of their whole inheritance tree,
+// handle is the index of the object handle.
+type objectPostProc func(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error)
+
+// knownObjectPostProcs maps serialized class signatures to objectPostProc implementations,
+// they also apply to all subclasses.
+var knownObjectPostProcs = map[string]objectPostProc{
+	"java.lang.Throwable@d5c635273977b8cb":  throwablePostProc,
+	"java.sql.Timestamp@2618d5c80153bf65":   sqlTimestampPostProc,
+	"java.sql.Date@14fa46683f356697":        sqlDatePostProc,
+	"java.sql.Time@74894a0dd932c471":        sqlTimePostProc,
+	"java.util.Properties@3912d07a70363e98": propertiesPostProc,
 }
 
 // primitiveHandler are used to read primitive values.
@@ -160,8 +339,23 @@ type JavaObjectParser struct {
 	buf                 bytes.Buffer
 	rd                  *bufio.Reader
 	handles             []interface{}
+	inProgress          map[int]bool
 	maxDataBlockSize    int
 	cycleReferenceValue string
+	orderedMaps         bool
+	mapKeyPolicy        MapKeyPolicy
+	byteArrayFormat     ByteArrayFormat
+	longFormat          LongFormat
+	nonFiniteFormat     NonFiniteFormat
+	enumFormat          EnumFormat
+	classFormat         ClassFormat
+	referenceMode       bool
+	recordMarker        bool
+	recordComponents    map[string]map[string]string
+	references          map[identity]int
+	referenceIDs        map[identity]string
+	handlerPacks        []handlerPack
+	classes             []*clazz
 }
 
 // clazz contains java class info.
@@ -173,6 +367,7 @@ type clazz struct {
 	name             string
 	flags            uint8
 	isEnum           bool
+	isRecord         bool
 }
 
 // field contains info about a single class member.
@@ -184,6 +379,25 @@ type field struct {
 
 type endBlockT string
 
+// mapEntry contains a single java map key/value pair.
+type mapEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+// cycleReference is a reference to the handle of an object which is still being parsed,
+// it is replaced with the cycle reference value by output.
+type cycleReference int
+
+// javaEnum is an enum constant, it is converted according to the enum format by output.
+type javaEnum struct {
+	cls  *clazz
+	name string
+}
+
+// javaMap contains java map entries in stream order, it is converted to the configured map type by output.
+type javaMap []mapEntry
+
 // parser is a func capable of reading a single serialized type.
 type parser func(jop *JavaObjectParser) (interface{}, error)
 
@@ -207,6 +421,18 @@ func init() {
 	}
 }
 
+// primitiveDefaults includes the Java default values of primitive types, object types default to null.
+var primitiveDefaults = map[byte]interface{}{
+	'B': int8(0),
+	'C': charString(0),
+	'D': float64(0),
+	'F': float32(0),
+	'I': int32(0),
+	'J': int64(0),
+	'S': int16(0),
+	'Z': false,
+}
+
 // primitiveHandlers maps serialized primitive identifiers to a corresponding primitiveHandler.
 var primitiveHandlers = map[string]primitiveHandler{
 	"B": func(jop *JavaObjectParser) (b interface{}, err error) {
@@ -221,7 +447,7 @@ var primitiveHandlers = map[string]primitiveHandler{
 		if charCode, err = jop.readUInt16(); err != nil {
 			err = errors.Wrap(err, "error reading char primitive")
 		} else {
-			char = string(rune(charCode))
+			char = charString(charCode)
 		}
 
 		return
@@ -324,8 +550,8 @@ func (jop *JavaObjectParser) content(allowedNames map[string]bool) (content inte
 	value, err := parse(jop)
 
 	if valueMap, isMap := value.(map[string]interface{}); isMap {
-		if valueMap[objectValueField] != nil {
-			value = valueMap[objectValueField]
+		if objectValue, exists := valueMap[objectValueField]; exists {
+			value = objectValue
 		} else {
 			delete(valueMap, "@")
 			delete(valueMap, "class")
@@ -610,6 +836,7 @@ func parseClassDesc(jop *JavaObjectParser) (x interface{}, err error) {
 	}
 
 	jop.newHandle(cls)
+	jop.classes = append(jop.classes, cls)
 	if cls.flags, err = jop.readUInt8(); err != nil {
 		err = errors.Wrap(err, "error reading class flags")
 		return
@@ -642,6 +869,7 @@ func parseClassDesc(jop *JavaObjectParser) (x interface{}, err error) {
 		return
 	}
 
+	cls.isRecord = cls.super != nil && cls.super.name == recordClassName
 	x = cls
 	return
 }
@@ -664,11 +892,15 @@ func parseReference(jop *JavaObjectParser) (ref interface{}, err error) {
 	}
 
 	i := int(refIdx - refIdMask)
-	if i > -1 && i < len(jop.handles) {
+	if i < 0 || i >= len(jop.handles) {
+		err = errors.Errorf("invalid reference handle %#x", refIdx)
+		return
+	}
+
+	if jop.inProgress[i] {
+		ref = cycleReference(i)
+	} else {
 		ref = jop.handles[i]
-		if ref == nil {
-			ref = jop.cycleReferenceValue
-		}
 	}
 
 	return
@@ -681,19 +913,38 @@ func parseArray(jop *JavaObjectParser) (arr interface{}, err error) {
 		return
 	}
 
-	res := map[string]interface{}{
-		"class": cls,
-	}
-
-	jop.newHandle(res)
+	deferredHandle := jop.newDeferredHandle()
 	var size int32
 	if size, err = jop.readInt32(); err != nil {
 		err = errors.Wrap(err, "error reading array size")
 		return
 	}
 
-	res["length"] = size
 	if cls == nil {
+		arr = deferredHandle(nil)
+		return
+	}
+
+	if size < 0 {
+		err = errors.Errorf("invalid array size %d", size)
+		return
+	}
+
+	// Prevented to allocate an extremely large block of memory.
+	if width, isPrimitive := primitiveWidths[cls.name[1]]; isPrimitive && int64(size)*int64(width) > int64(jop.maxDataBlockSize) {
+		err = errors.Errorf("primitive array size (%d bytes) exceeds reader buffer size (%d). "+
+			"To increase the size, use the method SetMaxDataBlockSize or use bufio.Reader with a larger buffer size",
+			int64(size)*int64(width), jop.maxDataBlockSize)
+		return
+	}
+
+	if array := newPrimitiveArray(cls.name[1], int(size)); array != nil {
+		if err = binary.Read(jop.rd, binary.BigEndian, array); err != nil {
+			err = errors.Wrap(err, "error reading primitive array")
+			return
+		}
+
+		arr = deferredHandle(array)
 		return
 	}
 
@@ -714,16 +965,83 @@ func parseArray(jop *JavaObjectParser) (arr interface{}, err error) {
 		array[i] = nxt
 	}
 
-	arr = array
+	arr = deferredHandle(array)
 	return
 }
 
-// newDeferredHandle reserves an object handle slot and returns a func which can set the slot value at a later time.
+// primitiveWidths includes the size in bytes of the serialized primitive array elements.
+var primitiveWidths = map[byte]int{
+	'B': 1,
+	'C': 2,
+	'D': 8,
+	'F': 4,
+	'I': 4,
+	'J': 8,
+	'S': 2,
+	'Z': 1,
+}
+
+// newPrimitiveArray creates a typed slice for a primitive array type, or returns nil for object arrays.
+func newPrimitiveArray(typeName byte, size int) interface{} {
+	switch typeName {
+	case 'B':
+		return make([]byte, size)
+	case 'C':
+		return make([]uint16, size)
+	case 'D':
+		return make([]float64, size)
+	case 'F':
+		return make([]float32, size)
+	case 'I':
+		return make([]int32, size)
+	case 'J':
+		return make([]int64, size)
+	case 'S':
+		return make([]int16, size)
+	case 'Z':
+		return make([]bool, size)
+	}
+
+	return nil
+}
+
+// charString converts a single UTF-16 code unit into a string, surrogates are escaped as \uXXXX.
+func charString(char uint16) string {
+	if utf16.IsSurrogate(rune(char)) {
+		return fmt.Sprintf("\\u%04X", char)
+	}
+
+	return string(rune(char))
+}
+
+// utf16String converts UTF-16 code units into a string combining surrogate pairs,
+// lone surrogates are escaped as \uXXXX.
+func utf16String(chars []uint16) string {
+	var sb strings.Builder
+	for i := 0; i < len(chars); i++ {
+		if i+1 < len(chars) && utf16.IsSurrogate(rune(chars[i])) {
+			if r := utf16.DecodeRune(rune(chars[i]), rune(chars[i+1])); r != unicode.ReplacementChar {
+				sb.WriteRune(r)
+				i++
+				continue
+			}
+		}
+
+		sb.WriteString(charString(chars[i]))
+	}
+
+	return sb.String()
+}
+
+// newDeferredHandle reserves an object handle slot in progress and returns a func which can set the slot value
+// at a later time, references to the slot while it is in progress are cyclic references.
 func (jop *JavaObjectParser) newDeferredHandle() func(interface{}) interface{} {
 	idx := len(jop.handles)
 	jop.handles = append(jop.handles, nil)
+	jop.inProgress[idx] = true
 	return func(obj interface{}) interface{} {
 		jop.handles[idx] = obj
+		delete(jop.inProgress, idx)
 		return obj
 	}
 }
@@ -742,12 +1060,13 @@ func parseEnum(jop *JavaObjectParser) (enum interface{}, err error) {
 		return
 	}
 
-	res := map[string]interface{}{
-		objectValueField: enumConstant,
-		"class":          cls,
+	name, isString := enumConstant.(string)
+	if !isString {
+		err = errors.New("unexpected enum constant name")
+		return
 	}
 
-	enum = deferredHandle(res)
+	enum = deferredHandle(javaEnum{cls: cls, name: name})
 	return
 }
 
@@ -874,12 +1193,26 @@ func (jop *JavaObjectParser) classData(cls *clazz) (data map[string]interface{},
 		data["@"] = anns
 	}
 
-	if postproc, exists := knownPostProcs[cls.name+"@"+cls.serialVersionUID]; exists {
+	if postproc, exists := jop.findPostProc(cls); exists {
 		data, err = postproc(data, anns)
 	}
 	return
 }
 
+// objectPostProc applies the objectPostProc of the nearest class in the inheritance tree, if any.
+func (jop *JavaObjectParser) objectPostProc(cls *clazz, obj map[string]interface{},
+	handle int) (map[string]interface{}, error) {
+	seen := map[*clazz]bool{}
+	for c := cls; c != nil && !seen[c]; c = c.super {
+		seen[c] = true
+		if postproc, exists := jop.findObjectPostProc(c); exists {
+			return postproc(cls, obj, handle)
+		}
+	}
+
+	return obj, nil
+}
+
 // recursiveClassData recursively reads inheritance tree until it reaches "java.lang.Object".
 func (jop *JavaObjectParser) recursiveClassData(cls *clazz, obj map[string]interface{},
 	seen map[*clazz]bool) error {
@@ -927,6 +1260,7 @@ func parseObject(jop *JavaObjectParser) (obj interface{}, err error) {
 		"extends": make(map[string]interface{}),
 	}
 
+	handle := len(jop.handles)
 	deferredHandle := jop.newDeferredHandle()
 	seen := map[*clazz]bool{}
 	if err = jop.recursiveClassData(cls, objMap, seen); err != nil {
@@ -934,10 +1268,35 @@ func parseObject(jop *JavaObjectParser) (obj interface{}, err error) {
 		return
 	}
 
+	if cls.isRecord {
+		jop.recordFields(cls, objMap)
+	}
+
+	if objMap, err = jop.objectPostProc(cls, objMap, handle); err != nil {
+		err = errors.Wrap(err, "error post processing object")
+		return
+	}
+
 	obj = deferredHandle(objMap)
 	return
 }
 
+// recordFields fills the record components missing from the stream with their default value
+// and adds the record marker when enabled.
+func (jop *JavaObjectParser) recordFields(cls *clazz, obj map[string]interface{}) {
+	for name, signature := range jop.recordComponents[cls.name] {
+		if _, exists := obj[name]; exists || len(signature) == 0 {
+			continue
+		}
+
+		obj[name] = primitiveDefaults[signature[0]]
+	}
+
+	if jop.recordMarker {
+		obj[recordField] = cls.name
+	}
+}
+
 // postProcSize reads the object size as an int32 from the first data element.
 func postProcSize(data []interface{}, offset int) (size int, err error) {
 	if len(data) < 1 {
@@ -972,6 +1331,35 @@ func primObjectPostProc(fields map[string]interface{}, data []interface{}) (map[
 	return fields, nil
 }
 
+// atomicBooleanPostProc populates the object value with the "value" int field as a bool.
+func atomicBooleanPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	value, isInt := fields["value"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected value value")
+	}
+
+	fields[objectValueField] = value != 0
+	return fields, nil
+}
+
+// fieldPostProc returns a postProc which populates the object value with the named field,
+// it is used to unwrap collection wrappers.
+func fieldPostProc(name string) postProc {
+	return func(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+		fields[objectValueField] = fields[name]
+		return fields, nil
+	}
+}
+
+// fieldObjectPostProc returns an objectPostProc which populates the object value with the named field,
+// unlike fieldPostProc the field may be declared by a super class.
+func fieldObjectPostProc(name string) objectPostProc {
+	return func(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+		obj[objectValueField] = obj[name]
+		return obj, nil
+	}
+}
+
 // listPostProc populates the object value with a []interface{}.
 func listPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
 	size, err := postProcSize(data, 0)
@@ -989,44 +1377,74 @@ func listPostProc(fields map[string]interface{}, data []interface{}) (map[string
 
 // mapPostProc populates the object value with a map of key/value pairs.
 func mapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
-	size, err := postProcSize(data, 4)
+	m, err := postProcMap(data, 4)
 	if err != nil {
 		return nil, err
 	}
 
-	if size*2+1 > len(data) {
-		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(data)-1)
-	}
+	fields[objectValueField] = m
+	return fields, nil
+}
 
-	m := make(map[string]interface{})
+// enumMapPostProc populates the object value with a map of key/value pairs where keys are enum constants.
+func enumMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	return sizedMapPostProc(fields, data)
+}
 
-	for i := 0; i < size; i++ {
-		key := data[2*i+1]
-		value := data[2*i+2]
-		m[fmt.Sprint(key)] = value
+// sizedMapPostProc populates the object value with a map of key/value pairs preceded by the map size.
+func sizedMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	m, err := postProcMap(data, 0)
+	if err != nil {
+		return nil, err
 	}
 
 	fields[objectValueField] = m
 	return fields, nil
 }
 
-// enumMapPostProc populates the object value with a map of key/value pairs where keys are enum constants.
-func enumMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
-	size, err := postProcSize(data, 0)
+// postProcMap reads the map size from the first data element at offset and the key/value pairs following it.
+func postProcMap(data []interface{}, offset int) (javaMap, error) {
+	size, err := postProcSize(data, offset)
 	if err != nil {
 		return nil, err
 	}
 
+	if size < 0 {
+		return nil, errors.Errorf("invalid map size %d", size)
+	}
+
 	if size*2+1 > len(data) {
 		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(data)-1)
 	}
 
-	m := make(map[string]interface{})
+	m := make(javaMap, size)
 
 	for i := 0; i < size; i++ {
-		key := data[2*i+1]
-		value := data[2*i+2]
-		m[fmt.Sprint(key)] = value
+		m[i] = mapEntry{key: data[2*i+1], value: data[2*i+2]}
+	}
+
+	return m, nil
+}
+
+// nullTerminatedMapPostProc populates the object value with a map of key/value pairs terminated by a null key.
+func nullTerminatedMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	m := javaMap{}
+
+	for i := 0; ; i += 2 {
+		if i >= len(data) {
+			return nil, errors.New("invalid data: missing null terminator")
+		}
+
+		key := data[i]
+		if key == nil {
+			break
+		}
+
+		if i+1 >= len(data) {
+			return nil, errors.Errorf("invalid data: missing value for key at position %d", i)
+		}
+
+		m = append(m, mapEntry{key: key, value: data[i+1]})
 	}
 
 	fields[objectValueField] = m
@@ -1054,6 +1472,222 @@ func hashSetPostProc(fields map[string]interface{}, data []interface{}) (map[str
 	return fields, nil
 }
 
+// treeSetPostProc populates the object value with a []interface{},
+// the set comparator precedes the set size and elements.
+func treeSetPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if len(data) < 1 {
+		return nil, errors.New("invalid data: comparator required")
+	}
+
+	return listPostProc(fields, data[1:])
+}
+
+// priorityQueuePostProc populates the object value with a []interface{} of "size" elements,
+// the first data element holds the queue capacity instead of its size.
+func priorityQueuePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, isInt := fields["size"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected size value")
+	}
+
+	if _, err := postProcSize(data, 0); err != nil {
+		return nil, err
+	}
+
+	if len(data) != int(size)+1 {
+		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(data)-1)
+	}
+
+	fields[objectValueField] = data[1:]
+	return fields, nil
+}
+
+// vectorPostProc populates the object value with the first "elementCount" elements of "elementData" field.
+func vectorPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	count, isInt := fields["elementCount"].(int32)
+	if !isInt || count < 0 {
+		return nil, errors.New("unexpected elementCount value")
+	}
+
+	elements, isSlice := fields["elementData"].([]interface{})
+	if !isSlice || len(elements) < int(count) {
+		return nil, errors.New("unexpected elementData value")
+	}
+
+	fields[objectValueField] = elements[:count]
+	return fields, nil
+}
+
+// arrayBlockingQueuePostProc populates the object value with the "count" elements of the circular "items" buffer
+// starting at "takeIndex".
+func arrayBlockingQueuePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	count, isInt := fields["count"].(int32)
+	if !isInt || count < 0 {
+		return nil, errors.New("unexpected count value")
+	}
+
+	takeIndex, isInt := fields["takeIndex"].(int32)
+	if !isInt || takeIndex < 0 {
+		return nil, errors.New("unexpected takeIndex value")
+	}
+
+	items, isSlice := fields["items"].([]interface{})
+	if !isSlice || len(items) < int(count) || int(takeIndex) >= len(items) && count > 0 {
+		return nil, errors.New("unexpected items value")
+	}
+
+	m := make([]interface{}, count)
+	for i := range m {
+		m[i] = items[(int(takeIndex)+i)%len(items)]
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
+
+// nullTerminatedListPostProc populates the object value with a []interface{} terminated by a null element.
+func nullTerminatedListPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	for i, elem := range data {
+		if elem == nil {
+			fields[objectValueField] = data[:i]
+			return fields, nil
+		}
+	}
+
+	return nil, errors.New("invalid data: missing null terminator")
+}
+
+// setFromMapPostProc populates the object value with the keys of the "m" backing map field,
+// the fields are left unchanged when the backing map is not a known map.
+func setFromMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	m, isMap := fields["m"].(javaMap)
+	if !isMap {
+		return fields, nil
+	}
+
+	elements := make([]interface{}, len(m))
+	for i, entry := range m {
+		elements[i] = entry.key
+	}
+
+	fields[objectValueField] = elements
+	return fields, nil
+}
+
+// singletonPostProc populates the object value with a []interface{} containing the "element" field.
+func singletonPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = []interface{}{fields["element"]}
+	return fields, nil
+}
+
+// singletonMapPostProc populates the object value with a map containing the "k" and "v" fields.
+func singletonMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = javaMap{{key: fields["k"], value: fields["v"]}}
+	return fields, nil
+}
+
+// emptyListPostProc populates the object value with an empty []interface{}.
+func emptyListPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = []interface{}{}
+	return fields, nil
+}
+
+// emptyMapPostProc populates the object value with an empty map.
+func emptyMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = javaMap{}
+	return fields, nil
+}
+
+// collSerPostProc populates the object value with a []interface{} or a map depending on the "tag" field,
+// maps are serialized as an array of alternating keys and values.
+func collSerPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	tag, isInt := fields["tag"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected tag value")
+	}
+
+	switch tag & collSerTagMask {
+	case collSerList, collSerSet, collSerListNulls:
+		return listPostProc(fields, data)
+	case collSerMap:
+		return collSerMapPostProc(fields, data)
+	default:
+		return nil, errors.Errorf("invalid collection tag %#x", tag)
+	}
+}
+
+// collSerMapPostProc populates the object value with the map entries written by a map serialization proxy,
+// the size counts both keys and values.
+func collSerMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size%2 != 0 || len(data) != size+1 {
+		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(data)-1)
+	}
+
+	m := make(javaMap, size/2)
+	for i := range m {
+		m[i] = mapEntry{key: data[2*i+1], value: data[2*i+2]}
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
+
+// stringBuilderPostProc populates the object value with a string of the first "count" chars of the char array,
+// the count precedes the char array.
+func stringBuilderPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	count, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 2 {
+		return nil, errors.New("invalid data: char array required")
+	}
+
+	value, err := stringValue(data[1], count)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = value
+	return fields, nil
+}
+
+// stringBufferPostProc populates the object value with a string of the first "count" chars of "value" field.
+func stringBufferPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	count, isInt := fields["count"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected count value")
+	}
+
+	value, err := stringValue(fields["value"], int(count))
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = value
+	return fields, nil
+}
+
+// stringValue converts the first count chars of a char array into a string.
+func stringValue(value interface{}, count int) (string, error) {
+	chars, isChars := value.([]uint16)
+	if !isChars {
+		return "", errors.New("unexpected string value")
+	}
+
+	if count < 0 || count > len(chars) {
+		return "", errors.Errorf("invalid count %d for %d chars", count, len(chars))
+	}
+
+	return utf16String(chars[:count]), nil
+}
+
 // datePostProc populates the object value with a time.Time.
 func datePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
 	if len(data) < 1 {
@@ -1078,6 +1712,44 @@ func datePostProc(fields map[string]interface{}, data []interface{}) (map[string
 	return fields, nil
 }
 
+// sqlTimestampPostProc populates the object value with a time.Time combining the date seconds with the "nanos" field.
+func sqlTimestampPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	t, isTime := obj[objectValueField].(time.Time)
+	if !isTime {
+		return nil, errors.New("unexpected timestamp date value")
+	}
+
+	nanos, isInt := obj["nanos"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected nanos value")
+	}
+
+	obj[objectValueField] = time.Unix(t.Unix(), int64(nanos))
+	return obj, nil
+}
+
+// sqlDatePostProc populates the object value with the local date formatted as "yyyy-mm-dd".
+func sqlDatePostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	t, isTime := obj[objectValueField].(time.Time)
+	if !isTime {
+		return nil, errors.New("unexpected date value")
+	}
+
+	obj[objectValueField] = t.Format(sqlDateLayout)
+	return obj, nil
+}
+
+// sqlTimePostProc populates the object value with the local time of day formatted as "hh:mm:ss[.fraction]".
+func sqlTimePostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	t, isTime := obj[objectValueField].(time.Time)
+	if !isTime {
+		return nil, errors.New("unexpected time value")
+	}
+
+	obj[objectValueField] = t.Format(sqlTimeLayout)
+	return obj, nil
+}
+
 // calendarPostProc populates the object value with a time.Time.
 func calendarPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
 	fields[objectValueField] = time.Unix(0, fields["time"].(int64)*int64(time.Millisecond))
@@ -1102,6 +1774,22 @@ func serPostProc(fields map[string]interface{}, data []interface{}) (map[string]
 	}
 
 	switch b[0] {
+	case serDurationType, serInstantType:
+		if len(b) < serSecondsNanosBlockSize {
+			return nil, errors.Errorf("incorrect data at position 0: wanted %d bytes, got %d", serSecondsNanosBlockSize, len(b))
+		}
+
+		var seconds int64
+		var nanos int32
+
+		binary.Read(bytes.NewReader(b[1:9]), binary.BigEndian, &seconds)
+		binary.Read(bytes.NewReader(b[9:13]), binary.BigEndian, &nanos)
+
+		if b[0] == serInstantType {
+			fields[objectValueField] = time.Unix(seconds, int64(nanos))
+		} else {
+			fields[objectValueField] = isoDuration(seconds, nanos)
+		}
 	case serLocalDateType:
 		if len(b) < serLocalDateBlockSize {
 			return nil, errors.Errorf("incorrect data at position 0: wanted %d bytes, got %d", serLocalDateBlockSize, len(b))
@@ -1152,3 +1840,457 @@ func serPostProc(fields map[string]interface{}, data []interface{}) (map[string]
 
 	return fields, nil
 }
+
+// isoDuration formats a duration of seconds and nanos as java.time.Duration does, e.g. "PT8H6M12.345S".
+func isoDuration(seconds int64, nanos int32) string {
+	if seconds == 0 && nanos == 0 {
+		return "PT0S"
+	}
+
+	effectiveSeconds := seconds
+	if seconds < 0 && nanos > 0 {
+		effectiveSeconds++
+	}
+
+	hours := effectiveSeconds / 3600
+	minutes := (effectiveSeconds % 3600) / 60
+	secs := effectiveSeconds % 60
+
+	var sb strings.Builder
+	sb.WriteString("PT")
+
+	if hours != 0 {
+		fmt.Fprintf(&sb, "%dH", hours)
+	}
+
+	if minutes != 0 {
+		fmt.Fprintf(&sb, "%dM", minutes)
+	}
+
+	if secs == 0 && nanos == 0 {
+		return sb.String()
+	}
+
+	if seconds < 0 && nanos > 0 && secs == 0 {
+		sb.WriteString("-0")
+	} else {
+		fmt.Fprintf(&sb, "%d", secs)
+	}
+
+	if nanos > 0 {
+		fraction := int64(nanos) + int64(time.Second)
+		if seconds < 0 {
+			fraction = 2*int64(time.Second) - int64(nanos)
+		}
+
+		sb.WriteString("." + strings.TrimRight(strconv.FormatInt(fraction, 10)[1:], "0"))
+	}
+
+	sb.WriteString("S")
+	return sb.String()
+}
+
+// uuidPostProc populates the object value with the canonical UUID string.
+func uuidPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	msb, isLong := fields["mostSigBits"].(int64)
+	if !isLong {
+		return nil, errors.New("unexpected mostSigBits value")
+	}
+
+	lsb, isLong := fields["leastSigBits"].(int64)
+	if !isLong {
+		return nil, errors.New("unexpected leastSigBits value")
+	}
+
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[0:8], uint64(msb))
+	binary.BigEndian.PutUint64(b[8:16], uint64(lsb))
+	h := hex.EncodeToString(b)
+
+	fields[objectValueField] = h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+	return fields, nil
+}
+
+// uriPostProc populates the object value with "string" field.
+func uriPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = fields["string"]
+	return fields, nil
+}
+
+// urlPostProc populates the object value with the URL external form.
+func urlPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	protocol, isString := fields["protocol"].(string)
+	if !isString {
+		return nil, errors.New("unexpected protocol value")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(protocol)
+	sb.WriteString(":")
+
+	if authority, _ := fields["authority"].(string); authority != "" {
+		sb.WriteString("//")
+		sb.WriteString(authority)
+	}
+
+	if file, _ := fields["file"].(string); file != "" {
+		sb.WriteString(file)
+	}
+
+	if ref, isString := fields["ref"].(string); isString {
+		sb.WriteString("#")
+		sb.WriteString(ref)
+	}
+
+	fields[objectValueField] = sb.String()
+	return fields, nil
+}
+
+// inetAddressPostProc populates the object value with the IPv4 literal,
+// IPv6 addresses are handled by inet6AddressPostProc.
+func inetAddressPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if family, _ := fields["family"].(int32); family != inetAddressFamilyIPv4 {
+		return fields, nil
+	}
+
+	address, isInt := fields["address"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected address value")
+	}
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(address))
+
+	fields[objectValueField] = ip.String()
+	return fields, nil
+}
+
+// inet6AddressPostProc populates the object value with the IPv6 literal, including the scope if any.
+func inet6AddressPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	address, isSlice := fields["ipaddress"].([]byte)
+	if !isSlice || len(address) != net.IPv6len {
+		return nil, errors.New("unexpected ipaddress value")
+	}
+
+	literal := net.IP(address).String()
+	if ifname, _ := fields["ifname"].(string); ifname != "" && fields["scope_ifname_set"] == true {
+		literal += "%" + ifname
+	} else if scopeID, _ := fields["scope_id"].(int32); fields["scope_id_set"] == true {
+		literal += "%" + strconv.Itoa(int(scopeID))
+	}
+
+	fields[objectValueField] = literal
+	return fields, nil
+}
+
+// inetSocketAddressPostProc populates the object value with "host:port".
+func inetSocketAddressPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	port, isInt := fields["port"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected port value")
+	}
+
+	host, isString := fields["hostname"].(string)
+	if !isString {
+		if host, isString = fields["addr"].(string); !isString {
+			return nil, errors.New("unexpected addr value")
+		}
+	}
+
+	fields[objectValueField] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	return fields, nil
+}
+
+// stackTraceElementPostProc populates the object value with the stack trace element as formatted by java.
+func stackTraceElementPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	declaringClass, isString := fields["declaringClass"].(string)
+	if !isString {
+		return nil, errors.New("unexpected declaringClass value")
+	}
+
+	lineNumber, isInt := fields["lineNumber"].(int32)
+	if !isInt {
+		return nil, errors.New("unexpected lineNumber value")
+	}
+
+	format, _ := fields["format"].(int8)
+	methodName, _ := fields["methodName"].(string)
+	fileName, hasFileName := fields["fileName"].(string)
+
+	var sb strings.Builder
+	if classLoaderName, _ := fields["classLoaderName"].(string); classLoaderName != "" &&
+		format&stackTraceBuiltinClassLoader == 0 {
+		sb.WriteString(classLoaderName)
+		sb.WriteString("/")
+	}
+
+	if moduleName, _ := fields["moduleName"].(string); moduleName != "" {
+		sb.WriteString(moduleName)
+		if moduleVersion, _ := fields["moduleVersion"].(string); moduleVersion != "" &&
+			format&stackTraceJDKNonUpgradeableModule == 0 {
+			sb.WriteString("@")
+			sb.WriteString(moduleVersion)
+		}
+	}
+
+	if sb.Len() > 0 {
+		sb.WriteString("/")
+	}
+
+	sb.WriteString(declaringClass)
+	sb.WriteString(".")
+	sb.WriteString(methodName)
+
+	switch {
+	case lineNumber == stackTraceNativeMethod:
+		sb.WriteString("(Native Method)")
+	case hasFileName && lineNumber >= 0:
+		sb.WriteString("(" + fileName + ":" + strconv.Itoa(int(lineNumber)) + ")")
+	case hasFileName:
+		sb.WriteString("(" + fileName + ")")
+	default:
+		sb.WriteString("(Unknown Source)")
+	}
+
+	fields[objectValueField] = sb.String()
+	return fields, nil
+}
+
+// throwablePostProc populates the object value with the class, message, stack trace, cause and suppressed
+// exceptions of a throwable, a cause referencing the throwable itself means there is no cause.
+func throwablePostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	cause := obj["cause"]
+	if ref, isRef := cause.(cycleReference); isRef && int(ref) == handle {
+		cause = nil
+	}
+
+	stackTrace, _ := obj["stackTrace"].([]interface{})
+	trace := make([]interface{}, len(stackTrace))
+	for i, elem := range stackTrace {
+		trace[i] = fmt.Sprint("at ", elem)
+	}
+
+	suppressed := obj["suppressedExceptions"]
+	if suppressed == nil {
+		suppressed = []interface{}{}
+	}
+
+	obj[objectValueField] = map[string]interface{}{
+		"class":      cls.name,
+		"message":    obj["detailMessage"],
+		"stackTrace": trace,
+		"cause":      cause,
+		"suppressed": suppressed,
+	}
+
+	return obj, nil
+}
+
+// serializedLambdaPostProc populates the object value with the implementation method, the functional interface
+// and the captured arguments of a serialized lambda or method reference.
+func serializedLambdaPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	implClass, isString := fields["implClass"].(string)
+	if !isString {
+		return nil, errors.New("unexpected implClass value")
+	}
+
+	implMethodName, isString := fields["implMethodName"].(string)
+	if !isString {
+		return nil, errors.New("unexpected implMethodName value")
+	}
+
+	functionalInterfaceClass, isString := fields["functionalInterfaceClass"].(string)
+	if !isString {
+		return nil, errors.New("unexpected functionalInterfaceClass value")
+	}
+
+	captured := fields["capturedArgs"]
+	if captured == nil {
+		captured = []interface{}{}
+	}
+
+	fields[objectValueField] = map[string]interface{}{
+		"lambda":    strings.ReplaceAll(implClass, "/", ".") + "::" + implMethodName,
+		"interface": strings.ReplaceAll(functionalInterfaceClass, "/", "."),
+		"captured":  captured,
+	}
+
+	return fields, nil
+}
+
+// localePostProc populates the object value with the BCP-47 language tag of the locale as Locale.toLanguageTag
+// returns it, e.g. "en-US", ill-formed variants are kept as a private use "lvariant" subtag.
+func localePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	language, _ := fields["language"].(string)
+	script, _ := fields["script"].(string)
+	region, _ := fields["country"].(string)
+	variant, _ := fields["variant"].(string)
+	extensions, _ := fields["extensions"].(string)
+
+	if !isLanguageTagSubtag(language, 2, 8, isASCIILetter) {
+		language = ""
+	} else if legacy, exists := legacyLanguageCodes[strings.ToLower(language)]; exists {
+		language = legacy
+	}
+
+	if language == "no" && region == "NO" && variant == "NY" {
+		language, variant = "nn", ""
+	}
+
+	var subtags []string
+	if isLanguageTagSubtag(script, 4, 4, isASCIILetter) {
+		subtags = append(subtags, strings.ToUpper(script[:1])+strings.ToLower(script[1:]))
+	}
+
+	if isLanguageTagSubtag(region, 2, 2, isASCIILetter) || isLanguageTagSubtag(region, 3, 3, isASCIIDigit) {
+		subtags = append(subtags, strings.ToUpper(region))
+	}
+
+	// well-formed variants are subtags, the ill-formed rest is kept as private use
+	var variants []string
+	if variant != "" {
+		variants = strings.Split(variant, "_")
+	}
+
+	for len(variants) > 0 && isVariantSubtag(variants[0]) {
+		subtags = append(subtags, variants[0])
+		variants = variants[1:]
+	}
+
+	var privateVariants []string
+	for len(variants) > 0 && isLanguageTagSubtag(variants[0], 1, 8, isASCIIAlphanumeric) {
+		privateVariants = append(privateVariants, variants[0])
+		variants = variants[1:]
+	}
+
+	// extensions are written as a tag suffix, where the "x" singleton starts the private use subtags
+	var privateUse []string
+	if extensions != "" {
+		exts := strings.Split(extensions, "-")
+		for i, ext := range exts {
+			if strings.EqualFold(ext, privateUseSingleton) {
+				privateUse = exts[i+1:]
+				break
+			}
+
+			subtags = append(subtags, strings.ToLower(ext))
+		}
+	}
+
+	if len(privateVariants) > 0 {
+		privateUse = append(append(privateUse, privateUseVariantPrefix), privateVariants...)
+	}
+
+	if language == "" && (len(subtags) > 0 || len(privateUse) == 0) {
+		language = undeterminedLanguageTag
+	}
+
+	var tag []string
+	if language != "" {
+		tag = append(tag, strings.ToLower(language))
+	}
+
+	tag = append(tag, subtags...)
+	if len(privateUse) > 0 {
+		tag = append(append(tag, privateUseSingleton), privateUse...)
+	}
+
+	fields[objectValueField] = strings.Join(tag, "-")
+	return fields, nil
+}
+
+// isLanguageTagSubtag reports whether a subtag has between minLength and maxLength characters accepted by isValid.
+func isLanguageTagSubtag(subtag string, minLength, maxLength int, isValid func(byte) bool) bool {
+	if len(subtag) < minLength || len(subtag) > maxLength {
+		return false
+	}
+
+	for i := 0; i < len(subtag); i++ {
+		if !isValid(subtag[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isVariantSubtag reports whether a locale variant is a well-formed BCP-47 variant subtag,
+// either 5 to 8 alphanumeric characters or a digit followed by 3 alphanumeric characters.
+func isVariantSubtag(subtag string) bool {
+	if len(subtag) == 4 {
+		return isASCIIDigit(subtag[0]) && isLanguageTagSubtag(subtag, 4, 4, isASCIIAlphanumeric)
+	}
+
+	return isLanguageTagSubtag(subtag, 5, 8, isASCIIAlphanumeric)
+}
+
+func isASCIILetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIIAlphanumeric(c byte) bool {
+	return isASCIILetter(c) || isASCIIDigit(c)
+}
+
+// patternPostProc populates the object value with the "pattern" and "flags" fields of a regular expression.
+func patternPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = map[string]interface{}{
+		"pattern": fields["pattern"],
+		"flags":   fields["flags"],
+	}
+
+	return fields, nil
+}
+
+// bitSetPostProc populates the object value with the indexes of the bits set in the "bits" field.
+func bitSetPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	bits, isSlice := fields["bits"].([]int64)
+	if !isSlice {
+		return nil, errors.New("unexpected bits value")
+	}
+
+	indexes := []interface{}{}
+	for i, word := range bits {
+		for bit := 0; bit < bitsPerLong; bit++ {
+			if uint64(word)&(1<<uint(bit)) != 0 {
+				indexes = append(indexes, int32(i*bitsPerLong+bit))
+			}
+		}
+	}
+
+	fields[objectValueField] = indexes
+	return fields, nil
+}
+
+// propertiesPostProc populates the object value with the properties merged with their "defaults" properties,
+// properties take precedence over the defaults.
+func propertiesPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	props, isMap := obj[objectValueField].(javaMap)
+	if !isMap {
+		return nil, errors.New("unexpected properties value")
+	}
+
+	defaults, _ := obj["defaults"].(javaMap)
+	merged := append(javaMap{}, props...)
+
+	for _, entry := range defaults {
+		overridden := false
+		for _, prop := range props {
+			if sameValue(prop.key, entry.key) {
+				overridden = true
+				break
+			}
+		}
+
+		if !overridden {
+			merged = append(merged, entry)
+		}
+	}
+
+	obj[objectValueField] = merged
+	return obj, nil
+}
@@ -1,9 +1,12 @@
 package java2json
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDate(t *testing.T) {
@@ -102,7 +105,603 @@ func TestCompose2(t *testing.T) {
 	parseInputAndCompareResult(t, input, expected)
 }
 
+func TestUUID(t *testing.T) {
+	input := "rO0ABXNyAA5qYXZhLnV0aWwuVVVJRLyZA/eYbYUvAgACSgAMbGVhc3RTaWdCaXRzSgALbW9zdFNpZ0JpdHN4cKRWQmYUF0AAEj5FZ+ibEtM="
+	expected := `"123e4567-e89b-12d3-a456-426614174000"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestURI(t *testing.T) {
+	input := "rO0ABXNyAAxqYXZhLm5ldC5VUkmsAXguQ55JqwMAAUwABnN0cmluZ3QAEkxqYXZhL2xhbmcvU3RyaW5nO3hwdAAibWFpbHRvOmpvaG5AZXhhbXBsZS5jb20/c3ViamVjdD1oaXg="
+	expected := `"mailto:john@example.com?subject=hi"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestURL(t *testing.T) {
+	input := "rO0ABXNyAAxqYXZhLm5ldC5VUkyWJTc2GvzkcgMAB0kACGhhc2hDb2RlSQAEcG9ydEwACWF1dGhvcml0eXQAEkxqYXZhL2xhbmcvU3RyaW5nO0wABGZpbGVxAH4AAUwABGhvc3RxAH4AAUwACHByb3RvY29scQB+AAFMAANyZWZxAH4AAXhw/////wAAIPt0ABBleGFtcGxlLmNvbTo4NDQzdAAJL3BhdGg/cT0xdAALZXhhbXBsZS5jb210AAVodHRwc3QABGZyYWd4"
+	expected := `"https://example.com:8443/path?q=1#frag"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestInet4Address(t *testing.T) {
+	input := "rO0ABXNyABRqYXZhLm5ldC5JbmV0QWRkcmVzcy2bV6+f4+vbAwADSQAHYWRkcmVzc0kABmZhbWlseUwACGhvc3ROYW1ldAASTGphdmEvbGFuZy9TdHJpbmc7eHDAqAABAAAAAXB4"
+	expected := `"192.168.0.1"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestInet6Address(t *testing.T) {
+	input := "rO0ABXNyABVqYXZhLm5ldC5JbmV0NkFkZHJlc3NffCCBUiyAIQMABUkACHNjb3BlX2lkWgAMc2NvcGVfaWRfc2V0WgAQc2NvcGVfaWZuYW1lX3NldEwABmlmbmFtZXQAEkxqYXZhL2xhbmcvU3RyaW5nO1sACWlwYWRkcmVzc3QAAltCeHIAFGphdmEubmV0LkluZXRBZGRyZXNzLZtXr5/j69sDAANJAAdhZGRyZXNzSQAGZmFtaWx5TAAIaG9zdE5hbWVxAH4AAXhwAAAAAAAAAAJweAAAAAIBAHB1cgACW0Ks8xf4BghU4AIAAHhwAAAAEP6AAAAAAAAAAAAAAAAAAAF4"
+	expected := `"fe80::1%2"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestInetSocketAddress(t *testing.T) {
+	input := "rO0ABXNyABpqYXZhLm5ldC5JbmV0U29ja2V0QWRkcmVzc0ZxlGFv+apFAwADSQAEcG9ydEwABGFkZHJ0ABZMamF2YS9uZXQvSW5ldEFkZHJlc3M7TAAIaG9zdG5hbWV0ABJMamF2YS9sYW5nL1N0cmluZzt4cAAAH5BzcgAUamF2YS5uZXQuSW5ldEFkZHJlc3Mtm1evn+Pr2wMAA0kAB2FkZHJlc3NJAAZmYW1pbHlMAAhob3N0TmFtZXEAfgACeHDAqAABAAAAAXB4cHg="
+	expected := `"192.168.0.1:8080"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestTreeMap(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuVHJlZU1hcAzB9j4tJWrmAwABTAAKY29tcGFyYXRvcnQAFkxqYXZhL3V0aWwvQ29tcGFyYXRvcjt4cHB3BAAAAAJ0AAFhdAABMXQAAWJ0AAEyeA=="
+	expected := `{"a":"1","b":"2"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestTreeSet(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuVHJlZVNldN2YUJOV7YdbAwAAeHBzcgAqamF2YS5sYW5nLlN0cmluZyRDYXNlSW5zZW5zaXRpdmVDb21wYXJhdG9ydwNcfVxQ5c4CAAB4cHcEAAAAA3QAAWF0AAFCdAABY3g="
+	expected := `["a","B","c"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestLinkedList(t *testing.T) {
+	input := "rO0ABXNyABRqYXZhLnV0aWwuTGlua2VkTGlzdAwpU11KYIgiAwAAeHB3BAAAAAN0AAJlMXQAAmUydAACZTN4"
+	expected := `["e1","e2","e3"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestPriorityQueue(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwuUHJpb3JpdHlRdWV1ZZTaMLT7P4KxAwACSQAEc2l6ZUwACmNvbXBhcmF0b3J0ABZMamF2YS91dGlsL0NvbXBhcmF0b3I7eHAAAAACcHcEAAAAA3QAAWF0AAFieA=="
+	expected := `["a","b"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestVector(t *testing.T) {
+	input := "rO0ABXNyABBqYXZhLnV0aWwuVmVjdG9y2Zd9W4A7rwEDAANJABFjYXBhY2l0eUluY3JlbWVudEkADGVsZW1lbnRDb3VudFsAC2VsZW1lbnREYXRhdAATW0xqYXZhL2xhbmcvT2JqZWN0O3hwAAAAAAAAAAJ1cgATW0xqYXZhLmxhbmcuT2JqZWN0O5DOWJ8QcylsAgAAeHAAAAAKdAACdjF0AAJ2MnBwcHBwcHBweA=="
+	expected := `["v1","v2"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestStack(t *testing.T) {
+	input := "rO0ABXNyAA9qYXZhLnV0aWwuU3RhY2sQ/irCuwmGHQIAAHhyABBqYXZhLnV0aWwuVmVjdG9y2Zd9W4A7rwEDAANJABFjYXBhY2l0eUluY3JlbWVudEkADGVsZW1lbnRDb3VudFsAC2VsZW1lbnREYXRhdAATW0xqYXZhL2xhbmcvT2JqZWN0O3hwAAAAAAAAAAJ1cgATW0xqYXZhLmxhbmcuT2JqZWN0O5DOWJ8QcylsAgAAeHAAAAAKdAACdjF0AAJ2MnBwcHBwcHBweA=="
+	expected := `["v1","v2"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestIdentityHashMap(t *testing.T) {
+	input := "rO0ABXNyABlqYXZhLnV0aWwuSWRlbnRpdHlIYXNoTWFwcaJlATPy6YADAAFJAARzaXpleHAAAAABdwQAAAABdAABa3QAAXZ4"
+	expected := `{"k":"v"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestLinkedHashSet(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwuTGlua2VkSGFzaFNldNhs11qV3SoeAgAAeHIAEWphdmEudXRpbC5IYXNoU2V0ukSFlZa4tzQDAAB4cHcMAAAAED9AAAAAAAADdAABenQAAWF0AAFteA=="
+	expected := `["z","a","m"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestConcurrentHashMap(t *testing.T) {
+	input := "rO0ABXNyACZqYXZhLnV0aWwuY29uY3VycmVudC5Db25jdXJyZW50SGFzaE1hcGSZ3hKdhyk9AwADSQALc2VnbWVudE1hc2tJAAxzZWdtZW50U2hpZnRbAAhzZWdtZW50c3QAMVtMamF2YS91dGlsL2NvbmN1cnJlbnQvQ29uY3VycmVudEhhc2hNYXAkU2VnbWVudDt4cAAAAAEAAAAfdXIAMVtMamF2YS51dGlsLmNvbmN1cnJlbnQuQ29uY3VycmVudEhhc2hNYXAkU2VnbWVudDtSd+Sm49atnAIAAHhwAAAAAnNyAC5qYXZhLnV0aWwuY29uY3VycmVudC5Db25jdXJyZW50SGFzaE1hcCRTZWdtZW50HzZMkFiTKT0CAAFGAApsb2FkRmFjdG9yeHIAKGphdmEudXRpbC5jb25jdXJyZW50LmxvY2tzLlJlZW50cmFudExvY2tmVagsLMhq6wIAAUwABHN5bmN0AC9MamF2YS91dGlsL2NvbmN1cnJlbnQvbG9ja3MvUmVlbnRyYW50TG9jayRTeW5jO3hwcD9AAABzcQB+AAVwP0AAAHQAAmsxdAACdjF0AAJrMnQAAnYycHB4"
+	expected := `{"k1":"v1","k2":"v2"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestConcurrentSkipListMap(t *testing.T) {
+	input := "rO0ABXNyACpqYXZhLnV0aWwuY29uY3VycmVudC5Db25jdXJyZW50U2tpcExpc3RNYXCIRnWuBhFGpwMAAUwACmNvbXBhcmF0b3J0ABZMamF2YS91dGlsL0NvbXBhcmF0b3I7eHBwdAABYXQAATF0AAFidAABMnB4"
+	expected := `{"a":"1","b":"2"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestConcurrentSkipListSet(t *testing.T) {
+	input := "rO0ABXNyACpqYXZhLnV0aWwuY29uY3VycmVudC5Db25jdXJyZW50U2tpcExpc3RTZXTdmFB5vc/xWwIAAUwAAW10AC1MamF2YS91dGlsL2NvbmN1cnJlbnQvQ29uY3VycmVudE5hdmlnYWJsZU1hcDt4cHNyACpqYXZhLnV0aWwuY29uY3VycmVudC5Db25jdXJyZW50U2tpcExpc3RNYXCIRnWuBhFGpwMAAUwACmNvbXBhcmF0b3J0ABZMamF2YS91dGlsL0NvbXBhcmF0b3I7eHBwdAABYXNyABFqYXZhLmxhbmcuQm9vbGVhbs0gcoDVnPruAgABWgAFdmFsdWV4cAF0AAFic3EAfgAHAXB4"
+	expected := `["a","b"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestLinkedBlockingQueue(t *testing.T) {
+	input := "rO0ABXNyAChqYXZhLnV0aWwuY29uY3VycmVudC5MaW5rZWRCbG9ja2luZ1F1ZXVloDBMoEDlgfYDAAZJAAhjYXBhY2l0eUwABWNvdW50dAArTGphdmEvdXRpbC9jb25jdXJyZW50L2F0b21pYy9BdG9taWNJbnRlZ2VyO0wACG5vdEVtcHR5dAAmTGphdmEvdXRpbC9jb25jdXJyZW50L2xvY2tzL0NvbmRpdGlvbjtMAAdub3RGdWxscQB+AAJMAAdwdXRMb2NrdAAqTGphdmEvdXRpbC9jb25jdXJyZW50L2xvY2tzL1JlZW50cmFudExvY2s7TAAIdGFrZUxvY2txAH4AA3hwf////3NyAClqYXZhLnV0aWwuY29uY3VycmVudC5hdG9taWMuQXRvbWljSW50ZWdlclY/XsyMbBaKAgABSQAFdmFsdWV4cgAQamF2YS5sYW5nLk51bWJlcoaslR0LlOCLAgAAeHAAAAACcHBwcHQAAnExdAACcTJweA=="
+	expected := `["q1","q2"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestArrayBlockingQueue(t *testing.T) {
+	input := "rO0ABXNyACdqYXZhLnV0aWwuY29uY3VycmVudC5BcnJheUJsb2NraW5nUXVldWX0pjG0HhBvhgIAB0kABWNvdW50SQAIcHV0SW5kZXhJAAl0YWtlSW5kZXhbAAVpdGVtc3QAE1tMamF2YS9sYW5nL09iamVjdDtMAARsb2NrdAAqTGphdmEvdXRpbC9jb25jdXJyZW50L2xvY2tzL1JlZW50cmFudExvY2s7TAAIbm90RW1wdHl0ACZMamF2YS91dGlsL2NvbmN1cnJlbnQvbG9ja3MvQ29uZGl0aW9uO0wAB25vdEZ1bGxxAH4AA3hwAAAAAwAAAAEAAAACdXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAABHQAAWNwdAABYXQAAWJwcHA="
+	expected := `["a","b","c"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestOrderedMaps(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwuTGlua2VkSGFzaE1hcDTATlwQbMD7AgABWgALYWNjZXNzT3JkZXJ4cgARamF2YS51dGlsLkhhc2hNYXAFB9rBwxZg0QMAAkYACmxvYWRGYWN0b3JJAAl0aHJlc2hvbGR4cD9AAAAAAAAMdwgAAAAQAAAAA3QABHpldGF0AAExdAAFYWxwaGF0AAEydAADbWlkdAABM3gA"
+	expected := `{"zeta":"1","alpha":"2","mid":"3"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetOrderedMaps(true)
+	})
+}
+
+func TestOrderedMapsNested(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwuTGlua2VkSGFzaE1hcDTATlwQbMD7AgABWgALYWNjZXNzT3JkZXJ4cgARamF2YS51dGlsLkhhc2hNYXAFB9rBwxZg0QMAAkYACmxvYWRGYWN0b3JJAAl0aHJlc2hvbGR4cD9AAAAAAAAMdwgAAAAQAAAAAnQABW91dGVyc3EAfgAAP0AAAAAAAAx3CAAAABAAAAACdAABYnQAATF0AAFhdAABMngAdAAEbGlzdHNyABNqYXZhLnV0aWwuQXJyYXlMaXN0eIHSHZnHYZ0DAAFJAARzaXpleHAAAAABdwQAAAABdAABeHh4AA=="
+	expected := `{"outer":{"b":"1","a":"2"},"list":["x"]}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetOrderedMaps(true)
+	})
+}
+
+func TestUnorderedMaps(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwuTGlua2VkSGFzaE1hcDTATlwQbMD7AgABWgALYWNjZXNzT3JkZXJ4cgARamF2YS51dGlsLkhhc2hNYXAFB9rBwxZg0QMAAkYACmxvYWRGYWN0b3JJAAl0aHJlc2hvbGR4cD9AAAAAAAAMdwgAAAAQAAAAA3QABHpldGF0AAExdAAFYWxwaGF0AAEydAADbWlkdAABM3gA"
+	expected := `{"alpha":"2","mid":"3","zeta":"1"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestMapKeySprint(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAABc3IAD2NvbS5leGFtcGxlLktleQAAAAAAAAABAgACSQACaWRMAARuYW1ldAASTGphdmEvbGFuZy9TdHJpbmc7eHAAAAABdAABeHQAAXZ4"
+	expected := `{"map[id:1 name:x]":"v"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestMapKeyJSON(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAABc3IAD2NvbS5leGFtcGxlLktleQAAAAAAAAABAgACSQACaWRMAARuYW1ldAASTGphdmEvbGFuZy9TdHJpbmc7eHAAAAABdAABeHQAAXZ4"
+	expected := `{"{\"id\":1,\"name\":\"x\"}":"v"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeyJSON)
+	})
+}
+
+func TestMapKeyEntries(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAACc3IADmphdmEubGFuZy5Mb25nO4vkkMyPI98CAAFKAAV2YWx1ZXhyABBqYXZhLmxhbmcuTnVtYmVyhqyVHQuU4IsCAAB4cAAgAAAAAAABdAADYmlnc3EAfgACAAAAAAAAAAF0AANvbmV4"
+	expected := `[{"key":9007199254740993,"value":"big"},{"key":1,"value":"one"}]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeyEntries)
+	})
+}
+
+func TestMapKeyStrict(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAACdAABMXQAAWFzcgAOamF2YS5sYW5nLkxvbmc7i+SQzI8j3wIAAUoABXZhbHVleHIAEGphdmEubGFuZy5OdW1iZXKGrJUdC5TgiwIAAHhwAAAAAAAAAAF0AAFieA=="
+	data, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		panic(err)
+	}
+
+	jop := NewJavaObjectParser(bytes.NewReader(data))
+	jop.SetMapKeyPolicy(MapKeyStrict)
+
+	if _, err = jop.ParseJavaObject(); err == nil {
+		t.Error("expected map key collision error")
+	}
+}
+
+func TestEnumSet(t *testing.T) {
+	input := "rO0ABXNyACRqYXZhLnV0aWwuRW51bVNldCRTZXJpYWxpemF0aW9uUHJveHkFB9PbdlTK0QIAAkwAC2VsZW1lbnRUeXBldAARTGphdmEvbGFuZy9DbGFzcztbAAhlbGVtZW50c3QAEVtMamF2YS9sYW5nL0VudW07eHB2cgARY29tLmV4YW1wbGUuQ29sb3IAAAAAAAAAABIAAHhyAA5qYXZhLmxhbmcuRW51bQAAAAAAAAAAEgAAeHB1cgARW0xqYXZhLmxhbmcuRW51bTuo+uK8jI8rOgIAAHhwAAAAAn5xAH4ABHQAA1JFRH5xAH4ABHQABEJMVUU="
+	expected := `["RED","BLUE"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestUnmodifiableList(t *testing.T) {
+	input := "rO0ABXNyACZqYXZhLnV0aWwuQ29sbGVjdGlvbnMkVW5tb2RpZmlhYmxlTGlzdPwPJTG17I4QAgABTAAEbGlzdHQAEExqYXZhL3V0aWwvTGlzdDt4cgAsamF2YS51dGlsLkNvbGxlY3Rpb25zJFVubW9kaWZpYWJsZUNvbGxlY3Rpb24ZQgCAy173HgIAAUwAAWN0ABZMamF2YS91dGlsL0NvbGxlY3Rpb247eHBzcgATamF2YS51dGlsLkFycmF5TGlzdHiB0h2Zx2GdAwABSQAEc2l6ZXhwAAAAAncEAAAAAnQAAWF0AAFieHEAfgAG"
+	expected := `["a","b"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestUnmodifiableMap(t *testing.T) {
+	input := "rO0ABXNyACVqYXZhLnV0aWwuQ29sbGVjdGlvbnMkVW5tb2RpZmlhYmxlTWFw8aWo/nT1B0ICAAFMAAFtdAAPTGphdmEvdXRpbC9NYXA7eHBzcgARamF2YS51dGlsLkhhc2hNYXAFB9rBwxZg0QMAAkYACmxvYWRGYWN0b3JJAAl0aHJlc2hvbGR4cD9AAAAAAAAMdwgAAAAQAAAAAXQAAWt0AAF2eA=="
+	expected := `{"k":"v"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSynchronizedSet(t *testing.T) {
+	input := "rO0ABXNyACVqYXZhLnV0aWwuQ29sbGVjdGlvbnMkU3luY2hyb25pemVkU2V0BsPCeQLu3zwCAAB4cgAsamF2YS51dGlsLkNvbGxlY3Rpb25zJFN5bmNocm9uaXplZENvbGxlY3Rpb24qYfhNCZyZtQMAAkwAAWN0ABZMamF2YS91dGlsL0NvbGxlY3Rpb247TAAFbXV0ZXh0ABJMamF2YS9sYW5nL09iamVjdDt4cHNyABFqYXZhLnV0aWwuSGFzaFNldLpEhZWWuLc0AwAAeHB3DAAAABA/QAAAAAAAAXQAAXh4cQB+AAR4"
+	expected := `["x"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSingletonList(t *testing.T) {
+	input := "rO0ABXNyACNqYXZhLnV0aWwuQ29sbGVjdGlvbnMkU2luZ2xldG9uTGlzdCrvKRA8p5uXAgABTAAHZWxlbWVudHQAEkxqYXZhL2xhbmcvT2JqZWN0O3hwdAAEb25seQ=="
+	expected := `["only"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSingletonMap(t *testing.T) {
+	input := "rO0ABXNyACJqYXZhLnV0aWwuQ29sbGVjdGlvbnMkU2luZ2xldG9uTWFwnyMJkXF/a5ECAAJMAAFrdAASTGphdmEvbGFuZy9PYmplY3Q7TAABdnEAfgABeHB0AANrZXl0AAN2YWw="
+	expected := `{"key":"val"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestEmptyList(t *testing.T) {
+	input := "rO0ABXNyAB9qYXZhLnV0aWwuQ29sbGVjdGlvbnMkRW1wdHlMaXN0ergXtDynnt4CAAB4cA=="
+	expected := `[]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestEmptyMap(t *testing.T) {
+	input := "rO0ABXNyAB5qYXZhLnV0aWwuQ29sbGVjdGlvbnMkRW1wdHlNYXBZNhSFWtzn0AIAAHhw"
+	expected := `{}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSetFromMap(t *testing.T) {
+	input := "rO0ABXNyACBqYXZhLnV0aWwuQ29sbGVjdGlvbnMkU2V0RnJvbU1hcCIQslBF8h/EAgABTAABbXQAD0xqYXZhL3V0aWwvTWFwO3hwc3IAEWphdmEudXRpbC5IYXNoTWFwBQfawcMWYNEDAAJGAApsb2FkRmFjdG9ySQAJdGhyZXNob2xkeHA/QAAAAAAADHcIAAAAEAAAAAJ0AAFhc3IAEWphdmEubGFuZy5Cb29sZWFuzSBygNWc+u4CAAFaAAV2YWx1ZXhwAXQAAWJzcQB+AAYBeA=="
+	expected := `["a","b"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestCollSerSet(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuQ29sbFNlcleOq7Y6G6gRAwABSQADdGFneHAAAAACdwQAAAACdAACczF0AAJzMng="
+	expected := `["s1","s2"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestCollSerMap(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuQ29sbFNlcleOq7Y6G6gRAwABSQADdGFneHAAAAADdwQAAAAEdAACazF0AAJ2MXQAAmsydAACdjJ4"
+	expected := `{"k1":"v1","k2":"v2"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestThrowable(t *testing.T) {
+	input := "rO0ABXNyAB9qYXZhLmxhbmcuSWxsZWdhbFN0YXRlRXhjZXB0aW9u5ldV5ppG8kgCAAB4cgAaamF2YS5sYW5nLlJ1bnRpbWVFeGNlcHRpb26eXwZHCjSD5QIAAHhyABNqYXZhLmxhbmcuRXhjZXB0aW9u0P0fPho7HMQCAAB4cgATamF2YS5sYW5nLlRocm93YWJsZdXGNSc5d7jLAwAETAAFY2F1c2V0ABVMamF2YS9sYW5nL1Rocm93YWJsZTtMAA1kZXRhaWxNZXNzYWdldAASTGphdmEvbGFuZy9TdHJpbmc7WwAKc3RhY2tUcmFjZXQAHltMamF2YS9sYW5nL1N0YWNrVHJhY2VFbGVtZW50O0wAFHN1cHByZXNzZWRFeGNlcHRpb25zdAAQTGphdmEvdXRpbC9MaXN0O3hwcQB+AAh0AARib29tdXIAHltMamF2YS5sYW5nLlN0YWNrVHJhY2VFbGVtZW50OwJGKjw8/SI5AgAAeHAAAAADc3IAG2phdmEubGFuZy5TdGFja1RyYWNlRWxlbWVudGEJxZomNt2FAgAESQAKbGluZU51bWJlckwADmRlY2xhcmluZ0NsYXNzcQB+AAVMAAhmaWxlTmFtZXEAfgAFTAAKbWV0aG9kTmFtZXEAfgAFeHAAAAAMdAAHY29tLkZvb3QACEZvby5qYXZhdAADYmFyc3EAfgAM/////3EAfgAOcHQABG1haW5zcQB+AAz////+dAAKc3VuLk5hdGl2ZXB0AARjYWxsc3IAJmphdmEudXRpbC5Db2xsZWN0aW9ucyRVbm1vZGlmaWFibGVMaXN0/A8lMbXsjhACAAFMAARsaXN0cQB+AAd4cgAsamF2YS51dGlsLkNvbGxlY3Rpb25zJFVubW9kaWZpYWJsZUNvbGxlY3Rpb24ZQgCAy173HgIAAUwAAWN0ABZMamF2YS91dGlsL0NvbGxlY3Rpb247eHBzcgATamF2YS51dGlsLkFycmF5TGlzdHiB0h2Zx2GdAwABSQAEc2l6ZXhwAAAAAHcEAAAAAHhxAH4AG3g="
+	expected := `{"cause":null,"class":"java.lang.IllegalStateException","message":"boom","stackTrace":["at com.Foo.bar(Foo.java:12)","at com.Foo.main(Unknown Source)","at sun.Native.call(Native Method)"],"suppressed":[]}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestThrowableCause(t *testing.T) {
+	input := "rO0ABXNyABpqYXZhLmxhbmcuUnVudGltZUV4Y2VwdGlvbp5fBkcKNIPlAgAAeHIAE2phdmEubGFuZy5FeGNlcHRpb27Q/R8+GjscxAIAAHhyABNqYXZhLmxhbmcuVGhyb3dhYmxl1cY1Jzl3uMsDAARMAAVjYXVzZXQAFUxqYXZhL2xhbmcvVGhyb3dhYmxlO0wADWRldGFpbE1lc3NhZ2V0ABJMamF2YS9sYW5nL1N0cmluZztbAApzdGFja1RyYWNldAAeW0xqYXZhL2xhbmcvU3RhY2tUcmFjZUVsZW1lbnQ7TAAUc3VwcHJlc3NlZEV4Y2VwdGlvbnN0ABBMamF2YS91dGlsL0xpc3Q7eHBzcgAfamF2YS5sYW5nLklsbGVnYWxTdGF0ZUV4Y2VwdGlvbuZXVeaaRvJIAgAAeHEAfgAAcQB+AAl0AAVpbm5lcnVyAB5bTGphdmEubGFuZy5TdGFja1RyYWNlRWxlbWVudDsCRio8PP0iOQIAAHhwAAAAAHNyACZqYXZhLnV0aWwuQ29sbGVjdGlvbnMkVW5tb2RpZmlhYmxlTGlzdPwPJTG17I4QAgABTAAEbGlzdHEAfgAGeHIALGphdmEudXRpbC5Db2xsZWN0aW9ucyRVbm1vZGlmaWFibGVDb2xsZWN0aW9uGUIAgMte9x4CAAFMAAFjdAAWTGphdmEvdXRpbC9Db2xsZWN0aW9uO3hwc3IAE2phdmEudXRpbC5BcnJheUxpc3R4gdIdmcdhnQMAAUkABHNpemV4cAAAAAB3BAAAAAB4cQB+ABJ4dAAFb3V0ZXJ1cQB+AAsAAAACc3IAG2phdmEubGFuZy5TdGFja1RyYWNlRWxlbWVudGEJxZomNt2FAgAIQgAGZm9ybWF0SQAKbGluZU51bWJlckwAD2NsYXNzTG9hZGVyTmFtZXEAfgAETAAOZGVjbGFyaW5nQ2xhc3NxAH4ABEwACGZpbGVOYW1lcQB+AARMAAptZXRob2ROYW1lcQB+AARMAAptb2R1bGVOYW1lcQB+AARMAA1tb2R1bGVWZXJzaW9ucQB+AAR4cAMAAAABdAADYXBwdAANamF2YS51dGlsLkZvb3QACEZvby5qYXZhdAADYmFydAAJamF2YS5iYXNldAACMTdzcQB+ABUAAAAAB3QABmxvYWRlcnQAB2NvbS5CYXJ0AAhCYXIuamF2YXQAA2JhenQAA21vZHQAAzEuMHNxAH4ADXNxAH4AEQAAAAB3BAAAAAB4cQB+ACV4"
+	expected := `{"cause":{"cause":null,"class":"java.lang.IllegalStateException","message":"inner","stackTrace":[],"suppressed":[]},"class":"java.lang.RuntimeException","message":"outer","stackTrace":["at java.base/java.util.Foo.bar(Foo.java:1)","at loader/mod@1.0/com.Bar.baz(Bar.java:7)"],"suppressed":[]}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSQLTimestamp(t *testing.T) {
+	input := "rO0ABXNyABJqYXZhLnNxbC5UaW1lc3RhbXAmGNXIAVO/ZQIAAUkABW5hbm9zeHIADmphdmEudXRpbC5EYXRlaGqBAUtZdBkDAAB4cHcIAAABkEbVgiB4KROB7A=="
+	expected := time.Date(2024, time.June, 23, 20, 41, 56, 689144300, time.UTC)
+	obj := parseInput(input)
+	if ts, isTime := obj.(time.Time); !isTime || !ts.Equal(expected) {
+		t.Errorf("%v != %v", obj, expected)
+	}
+}
+
+func TestSQLDate(t *testing.T) {
+	input := "rO0ABXNyAA1qYXZhLnNxbC5EYXRlFPpGaD81ZpcCAAB4cgAOamF2YS51dGlsLkRhdGVoaoEBS1l0GQMAAHhwdwgAAAGQRPeqAHg="
+	expected := `"2024-06-23"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSQLTime(t *testing.T) {
+	input := "rO0ABXNyAA1qYXZhLnNxbC5UaW1ldIlKDdkyxHECAAB4cgAOamF2YS51dGlsLkRhdGVoaoEBS1l0GQMAAHhwdwgAAAAABH4GQHg="
+	expected := `"` + time.UnixMilli(75368000).Format("15:04:05") + `"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestPrimitiveArrays(t *testing.T) {
+	input := "rO0ABXNyABJjb20uZXhhbXBsZS5BcnJheXMAAAAAAAAAAQIAB1sABWJvb2xzdAACW1pbAAVjaGFyc3QAAltDWwAHZG91Ymxlc3QAAltEWwAGZmxvYXRzdAACW0ZbAARpbnRzdAACW0lbAAVsb25nc3QAAltKWwAGc2hvcnRzdAACW1N4cHVyAAJbWlePIDkUuF3iAgAAeHAAAAACAQB1cgACW0OwJmaw4l2ErAIAAHhwAAAAAgBvAGt1cgACW0Q+powUq2NaHgIAAHhwAAAAAj/4AAAAAAAAwAAAAAAAAAB1cgACW0YLnIGJIuAMQgIAAHhwAAAAAT6AAAB1cgACW0lNumAmduqypQIAAHhwAAAAAwAAAAH////+AAAAA3VyAAJbSnggBLUSsXWTAgAAeHAAAAABAAABAAAAAAB1cgACW1Pvgy4G5V2w+gIAAHhwAAAAAgAH//k="
+	expected := `{"bools":[true,false],"chars":"ok","doubles":[1.5,-2],"floats":[0.25],"ints":[1,-2,3],"longs":[1099511627776],"shorts":[7,-7]}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestByteArray(t *testing.T) {
+	input := "rO0ABXVyAAJbQqzzF/gGCFTgAgAAeHAAAAAEaGn/AQ=="
+	expected := `[104,105,-1,1]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestByteArrayBase64(t *testing.T) {
+	input := "rO0ABXVyAAJbQqzzF/gGCFTgAgAAeHAAAAAEaGn/AQ=="
+	expected := `"aGn/AQ=="`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetByteArrayFormat(ByteArrayBase64)
+	})
+}
+
+func TestByteArrayHex(t *testing.T) {
+	input := "rO0ABXVyAAJbQqzzF/gGCFTgAgAAeHAAAAAEaGn/AQ=="
+	expected := `"6869ff01"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetByteArrayFormat(ByteArrayHex)
+	})
+}
+
+func TestByteArrayText(t *testing.T) {
+	input := "rO0ABXVyAAJbQqzzF/gGCFTgAgAAeHAAAAAGaMOpbGxv"
+	expected := `"héllo"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetByteArrayFormat(ByteArrayText)
+	})
+
+	input = "rO0ABXVyAAJbQqzzF/gGCFTgAgAAeHAAAAAEaGn/AQ=="
+	expected = `"aGn/AQ=="`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetByteArrayFormat(ByteArrayText)
+	})
+}
+
+func TestCharArray(t *testing.T) {
+	input := "rO0ABXVyAAJbQ7AmZrDiXYSsAgAAeHAAAAAFAGHYPd4AAGLYPQ=="
+	expected := `"a😀b\\uD83D"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestCharFields(t *testing.T) {
+	input := "rO0ABXNyABFjb20uZXhhbXBsZS5DaGFycwAAAAAAAAABAgACQwAEaGlnaEMABmxldHRlcnhw2D0A6Q=="
+	expected := `{"high":"\\uD83D","letter":"é"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestStringBuilder(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLmxhbmcuU3RyaW5nQnVpbGRlcjzV+xRaTGrLAwAAeHB3BAAAAAV1cgACW0OwJmaw4l2ErAIAAHhwAAAAEQBoAGUAbABsAG/YPd4AAAAAAAAAAAAAAAAAAAAAAAAAAAB4"
+	expected := `"hello"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestStringBuffer(t *testing.T) {
+	input := "rO0ABXNyABZqYXZhLmxhbmcuU3RyaW5nQnVmZmVyLwcH2erI6tMDAANJAAVjb3VudFoABnNoYXJlZFsABXZhbHVldAACW0N4cAAAAAcAdXIAAltDsCZmsOJdhKwCAAB4cAAAAAoAaABlAGwAbABv2D3eAAAAAAAAAHg="
+	expected := `"hello😀"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestNumberFormats(t *testing.T) {
+	input := "rO0ABXNyABNjb20uZXhhbXBsZS5OdW1iZXJzAAAAAAAAAAECAAlEAANuYW5EAAZuZWdJbmZGAAZwb3NJbmZKAANiaWdKAAVzbWFsbFsAB2RvdWJsZXN0AAJbRFsABWxvbmdzdAACW0pMAAVib3hlZHQAEExqYXZhL2xhbmcvTG9uZztMAANtYXB0AA9MamF2YS91dGlsL01hcDt4cH/4AAAAAAAA//AAAAAAAAB/gAAAEAAAAAAAAAAAAAAAAAAAKnVyAAJbRD6mjBSrY1oeAgAAeHAAAAACf/gAAAAAAAA/+AAAAAAAAHVyAAJbSnggBLUSsXWTAgAAeHAAAAACAAAAAAAAAAHwAAAAAAAAAHNyAA5qYXZhLmxhbmcuTG9uZzuL5JDMjyPfAgABSgAFdmFsdWV4cgAQamF2YS5sYW5nLk51bWJlcoaslR0LlOCLAgAAeHAAIAAAAAAAAXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAABdAABZHNyABBqYXZhLmxhbmcuRG91YmxlgLPCSilr+wQCAAFEAAV2YWx1ZXhxAH4AC3/wAAAAAAAAeA=="
+	expected := `{"big":"1152921504606846976","boxed":"9007199254740993","doubles":["NaN",1.5],"longs":[1,"-1152921504606846976"],"map":{"d":"Infinity"},"nan":"NaN","negInf":"-Infinity","posInf":"Infinity","small":42}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetLongFormat(LongStringUnsafe)
+		jop.SetNonFiniteFormat(NonFiniteString)
+	})
+
+	expected = `{"big":"1152921504606846976","boxed":"9007199254740993","doubles":[null,1.5],"longs":["1","-1152921504606846976"],"map":{"d":null},"nan":null,"negInf":null,"posInf":null,"small":"42"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetLongFormat(LongString)
+		jop.SetNonFiniteFormat(NonFiniteNull)
+	})
+}
+
+func TestReferenceModeShared(t *testing.T) {
+	input := "rO0ABXNyABBjb20uZXhhbXBsZS5QYWlyAAAAAAAAAAECAAJMAAF4dAAQTGphdmEvdXRpbC9MaXN0O0wAAXlxAH4AAXhwc3IAE2phdmEudXRpbC5BcnJheUxpc3R4gdIdmcdhnQMAAUkABHNpemV4cAAAAAF3BAAAAAF0AAFheHEAfgAE"
+	expected := `{"x":["a"],"y":["a"]}`
+	parseInputAndCompareResult(t, input, expected)
+
+	expected = `{"x":{"$id":"1","$values":["a"]},"y":{"$ref":"1"}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetReferenceMode(true)
+	})
+}
+
+func TestReferenceModeMapKeys(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAADdAABYXNyABNqYXZhLnV0aWwuQXJyYXlMaXN0eIHSHZnHYZ0DAAFJAARzaXpleHAAAAABdwQAAAABdAABeHhxAH4ABHQAAWJ0AAFjcQB+AAR4"
+	expected := `{"[x]":"b","a":{"$id":"1","$values":["x"]},"c":{"$ref":"1"}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeySprint)
+		jop.SetReferenceMode(true)
+	})
+
+	expected = `{"[\"x\"]":"b","a":{"$id":"1","$values":["x"]},"c":{"$ref":"1"}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeyJSON)
+		jop.SetReferenceMode(true)
+	})
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeyStrict)
+		jop.SetReferenceMode(true)
+	})
+}
+
+func TestReferenceModeMapEntries(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABAAAAACdAABYXNyABNqYXZhLnV0aWwuQXJyYXlMaXN0eIHSHZnHYZ0DAAFJAARzaXpleHAAAAABdwQAAAABdAABeHhxAH4ABHQAAWJ4"
+	expected := `[{"key":"a","value":{"$id":"1","$values":["x"]}},{"key":{"$ref":"1"},"value":"b"}]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetMapKeyPolicy(MapKeyEntries)
+		jop.SetReferenceMode(true)
+	})
+}
+
+func TestReferenceModeCycle(t *testing.T) {
+	input := "rO0ABXNyABBjb20uZXhhbXBsZS5Ob2RlAAAAAAAAAAECAAJMAARuYW1ldAASTGphdmEvbGFuZy9TdHJpbmc7TAAEbmV4dHQAEkxjb20vZXhhbXBsZS9Ob2RlO3hwdAACbjFzcQB+AAB0AAJuMnEAfgAD"
+	expected := `{"name":"n1","next":{"name":"n2","next":"[CYCLE]"}}`
+	parseInputAndCompareResult(t, input, expected)
+
+	expected = `{"$id":"1","name":"n1","next":{"name":"n2","next":{"$ref":"1"}}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetReferenceMode(true)
+	})
+}
+
+func TestArrayReference(t *testing.T) {
+	input := "rO0ABXNyABVjb20uZXhhbXBsZS5BcnJheVBhaXIAAAAAAAAAAQIAAlsAAXh0ABNbTGphdmEvbGFuZy9TdHJpbmc7WwABeXEAfgABeHB1cgATW0xqYXZhLmxhbmcuU3RyaW5nO63SVufpHXtHAgAAeHAAAAACdAABYXQAAWJxAH4ABA=="
+	expected := `{"x":["a","b"],"y":["a","b"]}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestArrayCycle(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAAJ0AAFhcQB+AAE="
+	expected := `["a","[CYCLE]"]`
+	parseInputAndCompareResult(t, input, expected)
+
+	expected = `{"$id":"1","$values":["a",{"$ref":"1"}]}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetReferenceMode(true)
+	})
+}
+
+func TestInvalidReference(t *testing.T) {
+	if _, err := ParseJavaObject([]byte{0xac, 0xed, 0x00, 0x05, 0x71, 0x00, 0x7e, 0x00, 0x01}); err == nil {
+		t.Error("expected invalid reference error")
+	}
+}
+
+func TestSerializedLambda(t *testing.T) {
+	input := "rO0ABXNyACFqYXZhLmxhbmcuaW52b2tlLlNlcmlhbGl6ZWRMYW1iZGFvYdCULCk2hQIACkkADmltcGxNZXRob2RLaW5kTAAMY2FwdHVyZWRBcmdzdAATW0xqYXZhL2xhbmcvT2JqZWN0O0wADmNhcHR1cmluZ0NsYXNzdAARTGphdmEvbGFuZy9DbGFzcztMABhmdW5jdGlvbmFsSW50ZXJmYWNlQ2xhc3N0ABJMamF2YS9sYW5nL1N0cmluZztMAB1mdW5jdGlvbmFsSW50ZXJmYWNlTWV0aG9kTmFtZXEAfgADTAAiZnVuY3Rpb25hbEludGVyZmFjZU1ldGhvZFNpZ25hdHVyZXEAfgADTAAJaW1wbENsYXNzcQB+AANMAA5pbXBsTWV0aG9kTmFtZXEAfgADTAATaW1wbE1ldGhvZFNpZ25hdHVyZXEAfgADTAAWaW5zdGFudGlhdGVkTWV0aG9kVHlwZXEAfgADeHAAAAAGdXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAAAnQAAXhzcgARamF2YS5sYW5nLkludGVnZXIS4qCk94GHOAIAAUkABXZhbHVleHIAEGphdmEubGFuZy5OdW1iZXKGrJUdC5TgiwIAAHhwAAAAAnZyAAtjb20uZm9vLkJhcgAAAAAAAAABAgAAeHB0ABtqYXZhL3V0aWwvZnVuY3Rpb24vRnVuY3Rpb250AAVhcHBseXQAJihMamF2YS9sYW5nL09iamVjdDspTGphdmEvbGFuZy9PYmplY3Q7dAALY29tL2Zvby9CYXJ0AA1sYW1iZGEkbWFpbiQwdABLKExqYXZhL2xhbmcvU3RyaW5nO0xqYXZhL2xhbmcvSW50ZWdlcjtMamF2YS9sYW5nL09iamVjdDspTGphdmEvbGFuZy9PYmplY3Q7cQB+AA8="
+	expected := `{"captured":["x",2],"interface":"java.util.function.Function","lambda":"com.foo.Bar::lambda$main$0"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestRecord(t *testing.T) {
+	input := "rO0ABXNyAA1jb20uZm9vLlBvaW50AAAAAAAAAAACAAJJAAF4SQABeXhyABBqYXZhLmxhbmcuUmVjb3JkAAAAAAAAAAACAAB4cAAAAAEAAAAC"
+	expected := `{"x":1,"y":2}`
+	parseInputAndCompareResult(t, input, expected)
+
+	expected = `{"@record":"com.foo.Point","x":1,"y":2}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetRecordMarker(true)
+	})
+}
+
+func TestRecordMissingComponents(t *testing.T) {
+	input := "rO0ABXNyAA1jb20uZm9vLlBvaW50AAAAAAAAAAACAAJJAAF4SQABeXhyABBqYXZhLmxhbmcuUmVjb3JkAAAAAAAAAAACAAB4cAAAAAEAAAAC"
+	expected := `{"label":null,"visible":false,"weight":0,"x":1,"y":2,"z":0}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.SetRecordComponents("com.foo.Point", map[string]string{
+			"x":       "I",
+			"y":       "I",
+			"z":       "J",
+			"weight":  "D",
+			"visible": "Z",
+			"label":   "Ljava/lang/String;",
+		})
+	})
+}
+
+func TestLocale(t *testing.T) {
+	input := "rO0ABXNyABBqYXZhLnV0aWwuTG9jYWxlfvgRYJww+ewDAAZJAAhoYXNoY29kZUwAB2NvdW50cnl0ABJMamF2YS9sYW5nL1N0cmluZztMAApleHRlbnNpb25zcQB+AAFMAAhsYW5ndWFnZXEAfgABTAAGc2NyaXB0cQB+AAFMAAd2YXJpYW50cQB+AAF4cP////90AAJVU3QAAHQAAmVudAAETGF0bnEAfgAEeA=="
+	expected := `"en-Latn-US"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestCurrency(t *testing.T) {
+	input := "rO0ABXNyABJqYXZhLnV0aWwuQ3VycmVuY3n9zZNKWRGpHwIAAUwADGN1cnJlbmN5Q29kZXQAEkxqYXZhL2xhbmcvU3RyaW5nO3hwdAADRVVS"
+	expected := `"EUR"`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestPattern(t *testing.T) {
+	input := "rO0ABXNyABdqYXZhLnV0aWwucmVnZXguUGF0dGVybkZn1WtuSQINAgACSQAFZmxhZ3NMAAdwYXR0ZXJudAASTGphdmEvbGFuZy9TdHJpbmc7eHAAAAACdAAEXmErJA=="
+	expected := `{"flags":2,"pattern":"^a+$"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestBitSet(t *testing.T) {
+	input := "rO0ABXNyABBqYXZhLnV0aWwuQml0U2V0bv2Ifjk0qyEDAAFMAARiaXRzdAACW0p4cHVyAAJbSnggBLUSsXWTAgAAeHAAAAACAAAAAAAAAAUAAAAAAAAAAXg="
+	expected := `[0,2,64]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestPropertiesDefaults(t *testing.T) {
+	input := "rO0ABXNyABRqYXZhLnV0aWwuUHJvcGVydGllczkS0HpwNj6YAgABTAAIZGVmYXVsdHN0ABZMamF2YS91dGlsL1Byb3BlcnRpZXM7eHIAE2phdmEudXRpbC5IYXNodGFibGUTuw8lIUrkuAMAAkYACmxvYWRGYWN0b3JJAAl0aHJlc2hvbGR4cD9AAAAAAAAIdwgAAAALAAAAAnQAAWF0AAExdAABYnQAATJ4c3EAfgAAP0AAAAAAAAh3CAAAAAsAAAACcQB+AAZ0AAF4dAABY3QAATN4cA=="
+	expected := `{"a":"1","b":"2","c":"3"}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestAtomics(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAAZzcgApamF2YS51dGlsLmNvbmN1cnJlbnQuYXRvbWljLkF0b21pY0ludGVnZXJWP17MjGwWigIAAUkABXZhbHVleHIAEGphdmEubGFuZy5OdW1iZXKGrJUdC5TgiwIAAHhwAAAAB3NyAClqYXZhLnV0aWwuY29uY3VycmVudC5hdG9taWMuQXRvbWljQm9vbGVhbkCYtwpPP/wzAgABSQAFdmFsdWV4cAAAAAFzcgAramF2YS51dGlsLmNvbmN1cnJlbnQuYXRvbWljLkF0b21pY1JlZmVyZW5jZeZXcdRVeFTGAgABTAAFdmFsdWV0ABJMamF2YS9sYW5nL09iamVjdDt4cHQAAXhzcgAuamF2YS51dGlsLmNvbmN1cnJlbnQuYXRvbWljLkF0b21pY0ludGVnZXJBcnJheSe4V1EzAL2LAgABTAAFYXJyYXl0AAJbSXhwdXIAAltJTbpgJnbqsqUCAAB4cAAAAAIAAAABAAAAAnNyADhqYXZhLnV0aWwuY29uY3VycmVudC5hdG9taWMuTG9uZ0FkZGVyJFNlcmlhbGl6YXRpb25Qcm94eWSZ3hKjfQo9AgABSgAFdmFsdWV4cAAAAAAAAAAqc3IAOmphdmEudXRpbC5jb25jdXJyZW50LmF0b21pYy5Eb3VibGVBZGRlciRTZXJpYWxpemF0aW9uUHJveHlkmd4So30KPQIAAUQABXZhbHVleHA/+AAAAAAAAA=="
+	expected := `[7,true,"x",[1,2],42,1.5]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestEnumFormat(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAAJ+cgAOY29tLmZvby5TdGF0dXMAAAAAAAAAABIAAHhyAA5qYXZhLmxhbmcuRW51bQAAAAAAAAAAEgAAeHB0AAZBQ1RJVkV+cgAOY29tLmZvby5Nb2RlJDEAAAAAAAAAABIAAHhyAAxjb20uZm9vLk1vZGUAAAAAAAAAABIAAHhxAH4AA3EAfgAF"
+	parseInputWithParserAndCompareResult(t, input, `["ACTIVE","ACTIVE"]`, func(jop *JavaObjectParser) {
+		jop.SetEnumFormat(EnumName)
+	})
+	parseInputWithParserAndCompareResult(t, input, `["com.foo.Status.ACTIVE","com.foo.Mode.ACTIVE"]`, func(jop *JavaObjectParser) {
+		jop.SetEnumFormat(EnumQualifiedName)
+	})
+	parseInputWithParserAndCompareResult(t, input, `[{"@enum":"com.foo.Status","name":"ACTIVE"},{"@enum":"com.foo.Mode","name":"ACTIVE"}]`, func(jop *JavaObjectParser) {
+		jop.SetEnumFormat(EnumObject)
+	})
+}
+
+func TestEnumMapKeyFormat(t *testing.T) {
+	input := "rO0ABXNyABFqYXZhLnV0aWwuRW51bU1hcAZdffe+kHyhAwABTAAHa2V5VHlwZXQAEUxqYXZhL2xhbmcvQ2xhc3M7eHB2cgAWQmFzZTY0RW5jb2RlciRFbnVtVHlwZQAAAAAAAAAAEgAAeHIADmphdmEubGFuZy5FbnVtAAAAAAAAAAASAAB4cHcEAAAAA35xAH4AA3QABkVOVU1fQXQABHZhbDF+cQB+AAN0AAZFTlVNX0J0AAR2YWwyfnEAfgADdAAGRU5VTV9DdAAEdmFsM3g="
+	parseInputWithParserAndCompareResult(t, input, `{"Base64Encoder$EnumType.ENUM_A":"val1","Base64Encoder$EnumType.ENUM_B":"val2","Base64Encoder$EnumType.ENUM_C":"val3"}`, func(jop *JavaObjectParser) {
+		jop.SetEnumFormat(EnumObject)
+	})
+	parseInputWithParserAndCompareResult(t, input, `[{"key":{"@enum":"Base64Encoder$EnumType","name":"ENUM_A"},"value":"val1"},{"key":{"@enum":"Base64Encoder$EnumType","name":"ENUM_B"},"value":"val2"},{"key":{"@enum":"Base64Encoder$EnumType","name":"ENUM_C"},"value":"val3"}]`, func(jop *JavaObjectParser) {
+		jop.SetEnumFormat(EnumObject)
+		jop.SetMapKeyPolicy(MapKeyEntries)
+	})
+}
+
+func TestClassValues(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAAR2cgAQamF2YS5sYW5nLlN0cmluZ6DwpDh6O7NCAgAAeHB2cgADaW50AAAAAAAAAAAAAAB4cHZyABNbTGphdmEubGFuZy5TdHJpbmc7rdJW5+kde0cCAAB4cHZyAANbW0kX9+RPGY+JPAIAAHhw"
+	parseInputAndCompareResult(t, input, `["java.lang.String","int","java.lang.String[]","int[][]"]`)
+	parseInputWithParserAndCompareResult(t, input, `[{"@classRef":"java.lang.String","suid":-6849794470754667710},{"@classRef":"int","suid":0},{"@classRef":"java.lang.String[]","suid":-5921575005990323385},{"@classRef":"int[][]","suid":1727100010502261052}]`, func(jop *JavaObjectParser) {
+		jop.SetClassFormat(ClassReference)
+	})
+}
+
+func TestNullClassValue(t *testing.T) {
+	parseInputAndCompareResult(t, "rO0ABXZw", `null`)
+}
+
+func TestNegativeMapSize(t *testing.T) {
+	buf, _ := base64.StdEncoding.DecodeString("rO0ABXNyABFqYXZhLnV0aWwuSGFzaE1hcAUH2sHDFmDRAwACRgAKbG9hZEZhY3RvckkACXRocmVzaG9sZHhwP0AAAAAAAAx3CAAAABD/////eA==")
+	if _, err := ParseJavaObject(buf); err == nil {
+		t.Error("expected invalid map size error")
+	}
+}
+
+func TestMalformedCollectionCounts(t *testing.T) {
+	inputs := []string{
+		"rO0ABXNyABBqYXZhLnV0aWwuVmVjdG9y2Zd9W4A7rwEDAANJABFjYXBhY2l0eUluY3JlbWVudEkADGVsZW1lbnRDb3VudFsAC2VsZW1lbnREYXRhdAATW0xqYXZhL2xhbmcvT2JqZWN0O3hwAAAAAP////91cgATW0xqYXZhLmxhbmcuT2JqZWN0O5DOWJ8QcylsAgAAeHAAAAABdAACdjF4",
+		"rO0ABXNyACdqYXZhLnV0aWwuY29uY3VycmVudC5BcnJheUJsb2NraW5nUXVldWX0pjG0HhBvhgIAB0kABWNvdW50SQAIcHV0SW5kZXhJAAl0YWtlSW5kZXhbAAVpdGVtc3QAE1tMamF2YS9sYW5nL09iamVjdDtMAARsb2NrdAAqTGphdmEvdXRpbC9jb25jdXJyZW50L2xvY2tzL1JlZW50cmFudExvY2s7TAAIbm90RW1wdHl0ACZMamF2YS91dGlsL2NvbmN1cnJlbnQvbG9ja3MvQ29uZGl0aW9uO0wAB25vdEZ1bGxxAH4AA3hw/////wAAAAAAAAAAdXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAAAXQAAWFwcHA=",
+		"rO0ABXNyACdqYXZhLnV0aWwuY29uY3VycmVudC5BcnJheUJsb2NraW5nUXVldWX0pjG0HhBvhgIAB0kABWNvdW50SQAIcHV0SW5kZXhJAAl0YWtlSW5kZXhbAAVpdGVtc3QAE1tMamF2YS9sYW5nL09iamVjdDtMAARsb2NrdAAqTGphdmEvdXRpbC9jb25jdXJyZW50L2xvY2tzL1JlZW50cmFudExvY2s7TAAIbm90RW1wdHl0ACZMamF2YS91dGlsL2NvbmN1cnJlbnQvbG9ja3MvQ29uZGl0aW9uO0wAB25vdEZ1bGxxAH4AA3hwAAAAAQAAAAD////+dXIAE1tMamF2YS5sYW5nLk9iamVjdDuQzlifEHMpbAIAAHhwAAAAAXQAAWFwcHA=",
+	}
+
+	for _, input := range inputs {
+		buf, _ := base64.StdEncoding.DecodeString(input)
+		if _, err := ParseJavaObject(buf); err == nil {
+			t.Errorf("expected invalid count error for %s", input)
+		}
+	}
+}
+
+func TestOversizedPrimitiveArray(t *testing.T) {
+	inputs := []string{
+		"rO0ABXVyAAJbSU26YCZ26rKlAgAAeHB/////",
+		"rO0ABXVyAAJbSnggBLUSsXWTAgAAeHAQAAAA",
+	}
+
+	for _, input := range inputs {
+		buf, _ := base64.StdEncoding.DecodeString(input)
+		if _, err := ParseJavaObject(buf); err == nil || !strings.Contains(err.Error(), "exceeds reader buffer size") {
+			t.Errorf("expected array size error for %s, got %v", input, err)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAARzcgANamF2YS50aW1lLlNlcpVdhLobIkiyDAAAeHB3DQEAAAAAAABx9BSQSEB4c3EAfgACdw0B//////////8dzWUAeHNxAH4AAncNAQAAAAAAAAAAAAAAAHhzcQB+AAJ3DQH////////x8AAAAAB4"
+	expected := `["PT8H6M12.345S","PT-0.5S","PT0S","PT-1H"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestSetFromUnknownMap(t *testing.T) {
+	input := "rO0ABXNyACBqYXZhLnV0aWwuQ29sbGVjdGlvbnMkU2V0RnJvbU1hcCIQslBF8h/EAgABTAABbXQAD0xqYXZhL3V0aWwvTWFwO3hwc3IADWNvbS5mb28uTXlNYXAAAAAAAAAAAQIAAUkABHNpemV4cAAAAAE="
+	expected := `{"m":{"size":1}}`
+	parseInputAndCompareResult(t, input, expected)
+}
+
+func TestLocaleLanguageTags(t *testing.T) {
+	input := "rO0ABXVyABNbTGphdmEubGFuZy5PYmplY3Q7kM5YnxBzKWwCAAB4cAAAAAdzcgAQamF2YS51dGlsLkxvY2FsZX74EWCcMPnsAwAGSQAIaGFzaGNvZGVMAAdjb3VudHJ5dAASTGphdmEvbGFuZy9TdHJpbmc7TAAKZXh0ZW5zaW9uc3EAfgADTAAIbGFuZ3VhZ2VxAH4AA0wABnNjcmlwdHEAfgADTAAHdmFyaWFudHEAfgADeHD/////dAACVVN0AAB0AAJlbnEAfgAGdAADV0lOeHNxAH4AAv////90AAJERXEAfgAGdAACZGVxAH4ABnQABVBPU0lYeHNxAH4AAv////90AAJKUHQAE3UtY2EtamFwYW5lc2UteC1mb290AAJqYXEAfgAGcQB+AAZ4c3EAfgAC/////3QAAklMcQB+AAZ0AAJpd3EAfgAGcQB+AAZ4c3EAfgAC/////3EAfgAGcQB+AAZxAH4ABnEAfgAGcQB+AAZ4c3EAfgAC/////3QAAk5PcQB+AAZ0AAJub3EAfgAGdAACTll4c3EAfgAC/////3EAfgAGcQB+AAZxAH4ABnEAfgAGcQB+AAh4"
+	expected := `["en-US-x-lvariant-WIN","de-DE-POSIX","ja-JP-u-ca-japanese-x-foo","he-IL","und","nn-NO","x-lvariant-WIN"]`
+	parseInputAndCompareResult(t, input, expected)
+}
+
 func parseInputAndCompareResult(t *testing.T, b64str string, expected string) {
+	data, err := json.Marshal(parseInput(b64str))
+	if err != nil {
+		panic(err)
+	}
+
+	output := string(data)
+	if output != expected {
+		t.Errorf("%s != %s", output, expected)
+	}
+}
+
+func parseInput(b64str string) interface{} {
 	bytes, err := base64.StdEncoding.DecodeString(b64str)
 	if err != nil {
 		panic(err)
@@ -113,7 +712,25 @@ func parseInputAndCompareResult(t *testing.T, b64str string, expected string) {
 		panic(err)
 	}
 
-	data, err := json.Marshal(obj)
+	return obj
+}
+
+func parseInputWithParserAndCompareResult(t *testing.T, b64str string, expected string, configure func(jop *JavaObjectParser)) {
+	data, err := base64.StdEncoding.DecodeString(b64str)
+	if err != nil {
+		panic(err)
+	}
+
+	jop := NewJavaObjectParser(bytes.NewReader(data))
+	jop.SetMaxDataBlockSize(len(data))
+	configure(jop)
+
+	obj, err := jop.ParseJavaObject()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err = json.Marshal(obj)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,241 @@
+package java2json
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// jodaHandlerPack renders Joda-Time instants, local dates and times, durations and periods.
+var jodaHandlerPack = handlerPack{
+	postProcs: map[string]postProc{
+		"org.joda.time.DateTimeZone$Stub":         jodaZoneStubPostProc,
+		"org.joda.time.chrono.ISOChronology$Stub": jodaChronologyStubPostProc,
+		"org.joda.time.Instant":                   jodaInstantPostProc,
+		"org.joda.time.LocalDate":                 jodaLocalDatePostProc,
+		"org.joda.time.LocalTime":                 jodaLocalTimePostProc,
+		"org.joda.time.LocalDateTime":             jodaLocalDateTimePostProc,
+	},
+	objectPostProcs: map[string]objectPostProc{
+		"org.joda.time.base.BaseDateTime": jodaDateTimePostProc,
+		"org.joda.time.base.BaseDuration": jodaDurationPostProc,
+		"org.joda.time.base.BasePeriod":   jodaPeriodPostProc,
+		// the zone is the "iParam" field declared by the AssembledChronology super class
+		"org.joda.time.chrono.ZonedChronology": fieldObjectPostProc("iParam"),
+	},
+}
+
+// jodaPeriodDesignators includes the ISO-8601 designators of the period fields in their printing order,
+// fields after "days" belong to the time part.
+var jodaPeriodDesignators = []struct {
+	name       string
+	designator string
+}{
+	{"years", "Y"},
+	{"months", "M"},
+	{"weeks", "W"},
+	{"days", "D"},
+	{"hours", "H"},
+	{"minutes", "M"},
+}
+
+const jodaPeriodTimeFieldsIndex int = 4
+
+// jodaZoneStubPostProc populates the object value with the time zone ID written by the zone stub.
+func jodaZoneStubPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if len(data) < 1 {
+		return nil, errors.New("invalid data: at least one element required")
+	}
+
+	b, isByteSlice := data[0].([]byte)
+	if !isByteSlice || len(b) < 2 || len(b) < 2+int(binary.BigEndian.Uint16(b)) {
+		return nil, errors.New("unexpected data at position 0")
+	}
+
+	fields[objectValueField] = string(b[2 : 2+int(binary.BigEndian.Uint16(b))])
+	return fields, nil
+}
+
+// jodaChronologyStubPostProc populates the object value with the time zone ID of the chronology.
+func jodaChronologyStubPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if len(data) < 1 {
+		return nil, errors.New("invalid data: zone required")
+	}
+
+	fields[objectValueField] = data[0]
+	return fields, nil
+}
+
+// jodaInstantPostProc populates the object value with a time.Time.
+func jodaInstantPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	millis, isLong := fields["iMillis"].(int64)
+	if !isLong {
+		return nil, errors.New("unexpected iMillis value")
+	}
+
+	fields[objectValueField] = time.Unix(0, millis*int64(time.Millisecond))
+	return fields, nil
+}
+
+// jodaLocalDatePostProc populates the object value with a time.Time at the start of the local date.
+func jodaLocalDatePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	t, err := jodaLocalMillis(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return fields, nil
+}
+
+// jodaLocalTimePostProc populates the object value with a time.Time at the local time of day.
+func jodaLocalTimePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	t, err := jodaLocalMillis(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = time.Date(0, time.Month(1), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+	return fields, nil
+}
+
+// jodaLocalDateTimePostProc populates the object value with a time.Time at the local date and time.
+func jodaLocalDateTimePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	t, err := jodaLocalMillis(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+	return fields, nil
+}
+
+// jodaLocalMillis returns the "iLocalMillis" field as a UTC time.Time holding the local date and time fields.
+func jodaLocalMillis(fields map[string]interface{}) (time.Time, error) {
+	millis, isLong := fields["iLocalMillis"].(int64)
+	if !isLong {
+		return time.Time{}, errors.New("unexpected iLocalMillis value")
+	}
+
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+}
+
+// jodaDateTimePostProc populates the object value with a time.Time in the time zone of the "iChronology" field,
+// the local time zone is used when the chronology has no zone like Joda-Time does for a null chronology.
+func jodaDateTimePostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	millis, isLong := obj["iMillis"].(int64)
+	if !isLong {
+		return nil, errors.New("unexpected iMillis value")
+	}
+
+	loc := time.Local
+	if zone, isString := obj["iChronology"].(string); isString {
+		var err error
+		if loc, err = jodaLocation(zone); err != nil {
+			return nil, err
+		}
+	}
+
+	obj[objectValueField] = time.Unix(0, millis*int64(time.Millisecond)).In(loc)
+	return obj, nil
+}
+
+// jodaLocation returns the location of a Joda time zone ID, either a region ID or a fixed offset such as "+02:00",
+// an error is returned when the time zone database does not know the ID.
+func jodaLocation(zone string) (*time.Location, error) {
+	if loc, err := time.LoadLocation(zone); err == nil {
+		return loc, nil
+	}
+
+	for _, layout := range []string{"-07:00", "-07:00:00"} {
+		if t, err := time.Parse(layout, strings.SplitN(zone, ".", 2)[0]); err == nil {
+			_, offset := t.Zone()
+			return time.FixedZone(zone, offset), nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown time zone %q", zone)
+}
+
+// jodaDurationPostProc populates the object value with the ISO-8601 duration, e.g. "PT72.345S".
+func jodaDurationPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	millis, isLong := obj["iMillis"].(int64)
+	if !isLong {
+		return nil, errors.New("unexpected iMillis value")
+	}
+
+	obj[objectValueField] = "PT" + jodaSeconds(millis) + "S"
+	return obj, nil
+}
+
+// jodaPeriodPostProc populates the object value with the ISO-8601 period, e.g. "P1Y2M3DT4H5M6.007S",
+// the period fields are named by the duration field types of the "iType" field.
+func jodaPeriodPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	periodType, isMap := obj["iType"].(map[string]interface{})
+	if !isMap {
+		return nil, errors.New("unexpected iType value")
+	}
+
+	types, isSlice := periodType["iTypes"].([]interface{})
+	if !isSlice {
+		return nil, errors.New("unexpected iTypes value")
+	}
+
+	values, isSlice := obj["iValues"].([]int32)
+	if !isSlice || len(values) != len(types) {
+		return nil, errors.New("unexpected iValues value")
+	}
+
+	period := make(map[string]int64, len(types))
+	for i, t := range types {
+		fieldType, isMap := t.(map[string]interface{})
+		if !isMap {
+			return nil, errors.Errorf("unexpected duration field type at position %d", i)
+		}
+
+		name, _ := fieldType["iName"].(string)
+		period[name] = int64(values[i])
+	}
+
+	var sb strings.Builder
+	sb.WriteString("P")
+
+	for i, d := range jodaPeriodDesignators {
+		if i == jodaPeriodTimeFieldsIndex && (period["hours"] != 0 || period["minutes"] != 0 ||
+			period["seconds"] != 0 || period["millis"] != 0) {
+			sb.WriteString("T")
+		}
+
+		if period[d.name] != 0 {
+			fmt.Fprintf(&sb, "%d%s", period[d.name], d.designator)
+		}
+	}
+
+	if millis := period["seconds"]*1000 + period["millis"]; millis != 0 {
+		sb.WriteString(jodaSeconds(millis) + "S")
+	}
+
+	if sb.Len() == 1 {
+		sb.WriteString("T0S")
+	}
+
+	obj[objectValueField] = sb.String()
+	return obj, nil
+}
+
+// jodaSeconds formats millis as seconds with three fraction digits when they are not whole seconds.
+func jodaSeconds(millis int64) string {
+	sign := ""
+	if millis < 0 {
+		sign, millis = "-", -millis
+	}
+
+	if millis%1000 == 0 {
+		return fmt.Sprintf("%s%d", sign, millis/1000)
+	}
+
+	return fmt.Sprintf("%s%d.%03d", sign, millis/1000, millis%1000)
+}
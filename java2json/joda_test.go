@@ -0,0 +1,87 @@
+package java2json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestJodaDateTime(t *testing.T) {
+	input := "rO0ABXNyABZvcmcuam9kYS50aW1lLkRhdGVUaW1luDx4ZGpb3fkCAAB4cgAfb3JnLmpvZGEudGltZS5iYXNlLkJhc2VEYXRlVGltZf//8eFdbl0uAgACSgAHaU1pbGxpc0wAC2lDaHJvbm9sb2d5dAAaTG9yZy9qb2RhL3RpbWUvQ2hyb25vbG9neTt4cAAAAW9kNcyOc3IAJ29yZy5qb2RhLnRpbWUuY2hyb25vLklTT0Nocm9ub2xvZ3kkU3R1YqbIEXByoOHiAwAAeHBzcgAfb3JnLmpvZGEudGltZS5EYXRlVGltZVpvbmUkU3R1Yv03O5rJ+P9xAwAAeHB3DwANRXVyb3BlL0Jlcmxpbnh4"
+	expected := `"2020-01-02T04:04:05.006+01:00"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
+
+func TestJodaDateTimeFixedOffset(t *testing.T) {
+	input := "rO0ABXNyABZvcmcuam9kYS50aW1lLkRhdGVUaW1luDx4ZGpb3fkCAAB4cgAfb3JnLmpvZGEudGltZS5iYXNlLkJhc2VEYXRlVGltZf//8eFdbl0uAgACSgAHaU1pbGxpc0wAC2lDaHJvbm9sb2d5dAAaTG9yZy9qb2RhL3RpbWUvQ2hyb25vbG9neTt4cAAAAW9kNcyOc3IAJ29yZy5qb2RhLnRpbWUuY2hyb25vLklTT0Nocm9ub2xvZ3kkU3R1YqbIEXByoOHiAwAAeHBzcgAfb3JnLmpvZGEudGltZS5EYXRlVGltZVpvbmUkU3R1Yv03O5rJ+P9xAwAAeHB3CAAGKzA1OjMweHg="
+	expected := `"2020-01-02T08:34:05.006+05:30"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
+
+func TestJodaZonedChronology(t *testing.T) {
+	input := "rO0ABXNyACRvcmcuam9kYS50aW1lLmNocm9uby5ab25lZENocm9ub2xvZ3nxBbPL8HkQgAIAAHhyAChvcmcuam9kYS50aW1lLmNocm9uby5Bc3NlbWJsZWRDaHJvbm9sb2d5op+v2aK3uPkCAAJMAAVpQmFzZXQAGkxvcmcvam9kYS90aW1lL0Nocm9ub2xvZ3k7TAAGaVBhcmFtdAASTGphdmEvbGFuZy9PYmplY3Q7eHIAI29yZy5qb2RhLnRpbWUuY2hyb25vLkJhc2VDaHJvbm9sb2d5moqWHFkagAUCAAB4cHNyACdvcmcuam9kYS50aW1lLmNocm9uby5JU09DaHJvbm9sb2d5JFN0dWKmyBFwcqDh4gMAAHhwc3IAH29yZy5qb2RhLnRpbWUuRGF0ZVRpbWVab25lJFN0dWL9Nzuayfj/cQMAAHhwdwUAA1VUQ3h4c3EAfgAIdw4ADEV1cm9wZS9QYXJpc3g="
+	expected := `"Europe/Paris"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+
+	input = "rO0ABXNyABZvcmcuam9kYS50aW1lLkRhdGVUaW1luDx4ZGpb3fkCAAB4cgAfb3JnLmpvZGEudGltZS5iYXNlLkJhc2VEYXRlVGltZf//8eFdbl0uAgACSgAHaU1pbGxpc0wAC2lDaHJvbm9sb2d5dAAaTG9yZy9qb2RhL3RpbWUvQ2hyb25vbG9neTt4cAAAAW9kNcyOc3IAJG9yZy5qb2RhLnRpbWUuY2hyb25vLlpvbmVkQ2hyb25vbG9nefEFs8vweRCAAgAAeHIAKG9yZy5qb2RhLnRpbWUuY2hyb25vLkFzc2VtYmxlZENocm9ub2xvZ3min6/Zore4+QIAAkwABWlCYXNlcQB+AAJMAAZpUGFyYW10ABJMamF2YS9sYW5nL09iamVjdDt4cgAjb3JnLmpvZGEudGltZS5jaHJvbm8uQmFzZUNocm9ub2xvZ3maipYcWRqABQIAAHhwc3IAJ29yZy5qb2RhLnRpbWUuY2hyb25vLklTT0Nocm9ub2xvZ3kkU3R1YqbIEXByoOHiAwAAeHBzcgAfb3JnLmpvZGEudGltZS5EYXRlVGltZVpvbmUkU3R1Yv03O5rJ+P9xAwAAeHB3BQADVVRDeHhzcQB+AAt3DgAMRXVyb3BlL1BhcmlzeA=="
+	expected = `"2020-01-02T04:04:05.006+01:00"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
+
+func TestJodaLocationSecondsOffset(t *testing.T) {
+	loc, err := jodaLocation("+05:30:15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, offset := time.Date(2020, time.January, 2, 0, 0, 0, 0, loc).Zone(); offset != 5*3600+30*60+15 {
+		t.Errorf("offset %d != %d", offset, 5*3600+30*60+15)
+	}
+}
+
+func TestJodaDateTimeUnknownZone(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString("rO0ABXNyABZvcmcuam9kYS50aW1lLkRhdGVUaW1luDx4ZGpb3fkCAAB4cgAfb3JnLmpvZGEudGltZS5iYXNlLkJhc2VEYXRlVGltZf//8eFdbl0uAgACSgAHaU1pbGxpc0wAC2lDaHJvbm9sb2d5dAAaTG9yZy9qb2RhL3RpbWUvQ2hyb25vbG9neTt4cAAAAW9kNcyOc3IAJ29yZy5qb2RhLnRpbWUuY2hyb25vLklTT0Nocm9ub2xvZ3kkU3R1YqbIEXByoOHiAwAAeHBzcgAfb3JnLmpvZGEudGltZS5EYXRlVGltZVpvbmUkU3R1Yv03O5rJ+P9xAwAAeHB3DgAMTWFycy9PbHltcHVzeHg=")
+	if err != nil {
+		panic(err)
+	}
+
+	jop := NewJavaObjectParser(bytes.NewReader(data))
+	jop.EnableHandlerPacks(JodaPack)
+
+	if _, err = jop.ParseJavaObject(); err == nil {
+		t.Error("expected unknown time zone error")
+	}
+}
+
+func TestJodaDuration(t *testing.T) {
+	input := "rO0ABXNyABZvcmcuam9kYS50aW1lLkR1cmF0aW9uJv9/0qGsDwICAAB4cgAfb3JnLmpvZGEudGltZS5iYXNlLkJhc2VEdXJhdGlvbiPC/H2Pn58GAgABSgAHaU1pbGxpc3hwAAAAAAABGpk="
+	expected := `"PT72.345S"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
+
+func TestJodaPeriod(t *testing.T) {
+	input := "rO0ABXNyABRvcmcuam9kYS50aW1lLlBlcmlvZALCzsE6XWqMAgAAeHIAHW9yZy5qb2RhLnRpbWUuYmFzZS5CYXNlUGVyaW9kTzqLSk9s2bUCAAJMAAVpVHlwZXQAGkxvcmcvam9kYS90aW1lL1BlcmlvZFR5cGU7TAAHaVZhbHVlc3QAAltJeHBzcgAYb3JnLmpvZGEudGltZS5QZXJpb2RUeXBlHp5dGxsbGxsCAANMAAhpSW5kaWNlc3EAfgADTAAFaU5hbWV0ABJMamF2YS9sYW5nL1N0cmluZztMAAZpVHlwZXN0ACJbTG9yZy9qb2RhL3RpbWUvRHVyYXRpb25GaWVsZFR5cGU7eHB1cgACW0lNumAmduqypQIAAHhwAAAACAAAAAAAAAABAAAAAgAAAAMAAAAEAAAABQAAAAYAAAAHdAAIU3RhbmRhcmR1cgAiW0xvcmcuam9kYS50aW1lLkR1cmF0aW9uRmllbGRUeXBlOzw8PDw8PDw8AgAAeHAAAAAIc3IAOW9yZy5qb2RhLnRpbWUuRHVyYXRpb25GaWVsZFR5cGUkU3RhbmRhcmREdXJhdGlvbkZpZWxkVHlwZSsrKysrKysrAgABQgAIaU9yZGluYWx4cgAfb3JnLmpvZGEudGltZS5EdXJhdGlvbkZpZWxkVHlwZRoaGhoaGhoaAgABTAAFaU5hbWVxAH4ABnhwdAAFeWVhcnMAc3EAfgAOdAAGbW9udGhzAXNxAH4ADnQABXdlZWtzAnNxAH4ADnQABGRheXMDc3EAfgAOdAAFaG91cnMEc3EAfgAOdAAHbWludXRlcwVzcQB+AA50AAdzZWNvbmRzBnNxAH4ADnQABm1pbGxpcwd1cQB+AAkAAAAIAAAAAQAAAAIAAAAAAAAAAwAAAAQAAAAAAAAABgAAAAc="
+	expected := `"P1Y2M3DT4H6.007S"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
+
+func TestJodaPeriodZero(t *testing.T) {
+	input := "rO0ABXNyABRvcmcuam9kYS50aW1lLlBlcmlvZALCzsE6XWqMAgAAeHIAHW9yZy5qb2RhLnRpbWUuYmFzZS5CYXNlUGVyaW9kTzqLSk9s2bUCAAJMAAVpVHlwZXQAGkxvcmcvam9kYS90aW1lL1BlcmlvZFR5cGU7TAAHaVZhbHVlc3QAAltJeHBzcgAYb3JnLmpvZGEudGltZS5QZXJpb2RUeXBlHp5dGxsbGxsCAANMAAhpSW5kaWNlc3EAfgADTAAFaU5hbWV0ABJMamF2YS9sYW5nL1N0cmluZztMAAZpVHlwZXN0ACJbTG9yZy9qb2RhL3RpbWUvRHVyYXRpb25GaWVsZFR5cGU7eHB1cgACW0lNumAmduqypQIAAHhwAAAACAAAAAAAAAABAAAAAgAAAAMAAAAEAAAABQAAAAYAAAAHdAAIU3RhbmRhcmR1cgAiW0xvcmcuam9kYS50aW1lLkR1cmF0aW9uRmllbGRUeXBlOzw8PDw8PDw8AgAAeHAAAAAIc3IAOW9yZy5qb2RhLnRpbWUuRHVyYXRpb25GaWVsZFR5cGUkU3RhbmRhcmREdXJhdGlvbkZpZWxkVHlwZSsrKysrKysrAgABQgAIaU9yZGluYWx4cgAfb3JnLmpvZGEudGltZS5EdXJhdGlvbkZpZWxkVHlwZRoaGhoaGhoaAgABTAAFaU5hbWVxAH4ABnhwdAAFeWVhcnMAc3EAfgAOdAAGbW9udGhzAXNxAH4ADnQABXdlZWtzAnNxAH4ADnQABGRheXMDc3EAfgAOdAAFaG91cnMEc3EAfgAOdAAHbWludXRlcwVzcQB+AA50AAdzZWNvbmRzBnNxAH4ADnQABm1pbGxpcwd1cQB+AAkAAAAIAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+	expected := `"PT0S"`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(JodaPack)
+	})
+}
@@ -0,0 +1,42 @@
+package java2json
+
+import "github.com/pkg/errors"
+
+// kotlinHandlerPack unwraps Kotlin collection builders and empty collections.
+var kotlinHandlerPack = handlerPack{
+	postProcs: map[string]postProc{
+		"kotlin.collections.builders.SerializedCollection": kotlinSerializedCollectionPostProc,
+		"kotlin.collections.builders.SerializedMap":        kotlinSerializedMapPostProc,
+		"kotlin.collections.EmptyList":                     emptyListPostProc,
+		"kotlin.collections.EmptySet":                      emptyListPostProc,
+		"kotlin.collections.EmptyMap":                      emptyMapPostProc,
+	},
+}
+
+// kotlinSerializedCollectionPostProc populates the object value with a []interface{},
+// the collection tag byte precedes the size and elements.
+func kotlinSerializedCollectionPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != size+1 {
+		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(data)-1)
+	}
+
+	fields[objectValueField] = data[1:]
+	return fields, nil
+}
+
+// kotlinSerializedMapPostProc populates the object value with a map of key/value pairs,
+// the tag byte precedes the map size and key/value pairs.
+func kotlinSerializedMapPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	m, err := postProcMap(data, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
@@ -0,0 +1,19 @@
+package java2json
+
+import "testing"
+
+func TestKotlinSerializedCollection(t *testing.T) {
+	input := "rO0ABXNyADBrb3RsaW4uY29sbGVjdGlvbnMuYnVpbGRlcnMuU2VyaWFsaXplZENvbGxlY3Rpb24AAAAAAAAAAAwAAHhwdwUAAAAAAnQAAWF0AAFieA=="
+	expected := `["a","b"]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(KotlinPack)
+	})
+}
+
+func TestKotlinSerializedMap(t *testing.T) {
+	input := "rO0ABXNyAClrb3RsaW4uY29sbGVjdGlvbnMuYnVpbGRlcnMuU2VyaWFsaXplZE1hcAAAAAAAAAAADAAAeHB3BQAAAAABdAABYXQAAWJ4"
+	expected := `{"a":"b"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(KotlinPack)
+	})
+}
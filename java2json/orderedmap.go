@@ -0,0 +1,74 @@
+package java2json
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// OrderedMap is a map which keeps its keys in insertion order,
+// it is used for java maps when the parser is configured with SetOrderedMaps.
+type OrderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewOrderedMap creates an empty OrderedMap.
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{
+		values: make(map[string]interface{}),
+	}
+}
+
+// Keys returns the map keys in insertion order.
+func (om *OrderedMap) Keys() []string {
+	return om.keys
+}
+
+// Get returns the value of a key and whether the key exists.
+func (om *OrderedMap) Get(key string) (value interface{}, exists bool) {
+	value, exists = om.values[key]
+	return
+}
+
+// Set sets the value of a key, a new key is appended to the end of the map.
+func (om *OrderedMap) Set(key string, value interface{}) {
+	if _, exists := om.values[key]; !exists {
+		om.keys = append(om.keys, key)
+	}
+
+	om.values[key] = value
+}
+
+// Len returns the number of keys.
+func (om *OrderedMap) Len() int {
+	return len(om.keys)
+}
+
+// MarshalJSON encodes the map as a JSON object keeping the key order.
+func (om *OrderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for i, key := range om.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := json.Marshal(om.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
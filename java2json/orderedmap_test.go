@@ -0,0 +1,35 @@
+package java2json
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	om := NewOrderedMap()
+	om.Set("b", 1)
+	om.Set("a", "x")
+	om.Set("b", 2)
+
+	if keys := om.Keys(); !reflect.DeepEqual(keys, []string{"b", "a"}) {
+		t.Errorf("unexpected keys %v", keys)
+	}
+
+	if value, exists := om.Get("b"); !exists || value != 2 {
+		t.Errorf("unexpected value %v", value)
+	}
+
+	if _, exists := om.Get("c"); exists {
+		t.Error("unexpected key c")
+	}
+
+	data, err := json.Marshal(om)
+	if err != nil {
+		panic(err)
+	}
+
+	if output, expected := string(data), `{"b":2,"a":"x"}`; output != expected {
+		t.Errorf("%s != %s", output, expected)
+	}
+}
@@ -0,0 +1,450 @@
+package java2json
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// MapKeyPolicy defines how java map keys are converted into JSON object keys.
+type MapKeyPolicy int
+
+const (
+	// MapKeySprint formats keys with fmt.Sprint.
+	MapKeySprint MapKeyPolicy = iota
+	// MapKeyJSON formats keys with their canonical JSON encoding, string keys are kept as is.
+	MapKeyJSON
+	// MapKeyEntries returns maps as a list of {"key": ..., "value": ...} entries.
+	MapKeyEntries
+	// MapKeyStrict formats keys like MapKeyJSON and fails when two distinct keys are formatted the same.
+	MapKeyStrict
+)
+
+// ByteArrayFormat defines how java byte arrays are returned.
+type ByteArrayFormat int
+
+const (
+	// ByteArrayNumbers returns byte arrays as a list of signed numbers.
+	ByteArrayNumbers ByteArrayFormat = iota
+	// ByteArrayBase64 returns byte arrays as a base64 string.
+	ByteArrayBase64
+	// ByteArrayHex returns byte arrays as a hex string.
+	ByteArrayHex
+	// ByteArrayText returns byte arrays as a string when they are valid UTF-8, otherwise as a base64 string.
+	ByteArrayText
+)
+
+// LongFormat defines how java long values are returned.
+type LongFormat int
+
+const (
+	// LongNumber returns longs as numbers.
+	LongNumber LongFormat = iota
+	// LongStringUnsafe returns longs as strings when they are beyond the JavaScript safe integer range.
+	LongStringUnsafe
+	// LongString returns longs as strings.
+	LongString
+)
+
+// NonFiniteFormat defines how NaN and infinite java float and double values are returned.
+type NonFiniteFormat int
+
+const (
+	// NonFiniteNumber returns non-finite values as numbers, which encoding/json is unable to marshal.
+	NonFiniteNumber NonFiniteFormat = iota
+	// NonFiniteString returns non-finite values as "NaN", "Infinity" or "-Infinity".
+	NonFiniteString
+	// NonFiniteNull returns non-finite values as nil.
+	NonFiniteNull
+)
+
+// EnumFormat defines how java enum constants are returned.
+type EnumFormat int
+
+const (
+	// EnumName returns enum constants as their name, e.g. "ACTIVE".
+	EnumName EnumFormat = iota
+	// EnumQualifiedName returns enum constants as their name qualified by the enum class name,
+	// e.g. "com.foo.Status.ACTIVE".
+	EnumQualifiedName
+	// EnumObject returns enum constants as {"@enum": "com.foo.Status", "name": "ACTIVE"}.
+	EnumObject
+)
+
+const enumClassField string = "@enum"
+const enumNameField string = "name"
+
+// ClassFormat defines how java class values are returned.
+type ClassFormat int
+
+const (
+	// ClassName returns class values as their name, array classes are named after their component type,
+	// e.g. "java.lang.String[]".
+	ClassName ClassFormat = iota
+	// ClassReference returns class values as {"@classRef": "java.lang.String", "suid": ...}.
+	ClassReference
+)
+
+const classRefField string = "@classRef"
+const classSUIDField string = "suid"
+
+// primitiveTypeNames maps JVM primitive type codes to java primitive type names.
+var primitiveTypeNames = map[byte]string{
+	'B': "byte",
+	'C': "char",
+	'D': "double",
+	'F': "float",
+	'I': "int",
+	'J': "long",
+	'S': "short",
+	'Z': "boolean",
+	'V': "void",
+}
+
+// maxSafeInteger is the largest integer that JavaScript numbers represent exactly.
+const maxSafeInteger int64 = 1<<53 - 1
+
+// output converts intermediate values of a parsed object into their final representation.
+func (jop *JavaObjectParser) output(value interface{}) (interface{}, error) {
+	if jop.references != nil {
+		if id, hasIdentity := identityOf(value); hasIdentity && jop.references[id] > 1 {
+			return jop.outputIdentified(id, value)
+		}
+	}
+
+	return jop.outputValue(value)
+}
+
+// outputValue converts a single intermediate value into its final representation.
+func (jop *JavaObjectParser) outputValue(value interface{}) (res interface{}, err error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range jop.objectKeys(v) {
+			if v[key], err = jop.output(v[key]); err != nil {
+				return
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			if v[i], err = jop.output(val); err != nil {
+				return
+			}
+		}
+	case *OrderedMap:
+		for _, key := range v.keys {
+			if v.values[key], err = jop.output(v.values[key]); err != nil {
+				return
+			}
+		}
+	case javaMap:
+		return jop.outputMap(v)
+	case javaEnum:
+		return jop.outputEnum(v), nil
+	case *clazz:
+		return jop.outputClass(v), nil
+	case cycleReference:
+		if jop.references != nil {
+			return jop.outputCycleReference(int(v)), nil
+		}
+
+		return jop.cycleReferenceValue, nil
+	case []byte:
+		return jop.outputBytes(v), nil
+	case []uint16:
+		return utf16String(v), nil
+	case int64:
+		return jop.outputLong(v), nil
+	case float64:
+		return jop.outputFloat(v, v), nil
+	case float32:
+		return jop.outputFloat(v, float64(v)), nil
+	case []int64:
+		if jop.longFormat != LongNumber {
+			longs := make([]interface{}, len(v))
+			for i, x := range v {
+				longs[i] = jop.outputLong(x)
+			}
+
+			return longs, nil
+		}
+	case []float64:
+		if jop.nonFiniteFormat != NonFiniteNumber {
+			floats := make([]interface{}, len(v))
+			for i, x := range v {
+				floats[i] = jop.outputFloat(x, x)
+			}
+
+			return floats, nil
+		}
+	case []float32:
+		if jop.nonFiniteFormat != NonFiniteNumber {
+			floats := make([]interface{}, len(v))
+			for i, x := range v {
+				floats[i] = jop.outputFloat(x, float64(x))
+			}
+
+			return floats, nil
+		}
+	}
+
+	return value, nil
+}
+
+// outputEnum converts a java enum constant according to the enum format.
+func (jop *JavaObjectParser) outputEnum(e javaEnum) interface{} {
+	switch jop.enumFormat {
+	case EnumQualifiedName:
+		return qualifiedEnumName(e)
+	case EnumObject:
+		return map[string]interface{}{enumClassField: enumType(e.cls), enumNameField: e.name}
+	}
+
+	return e.name
+}
+
+// qualifiedEnumName returns the name of an enum constant qualified by its enum class name.
+func qualifiedEnumName(e javaEnum) string {
+	return enumType(e.cls) + "." + e.name
+}
+
+// enumType returns the name of the enum class declaring a constant,
+// constants with a body are written with the descriptor of their anonymous subclass.
+func enumType(cls *clazz) string {
+	if cls == nil {
+		return ""
+	}
+
+	seen := map[*clazz]bool{cls: true}
+	for cls.super != nil && cls.super.name != enumClassName && !seen[cls.super] {
+		cls = cls.super
+		seen[cls] = true
+	}
+
+	return cls.name
+}
+
+// outputClass converts a java class value according to the class format.
+func (jop *JavaObjectParser) outputClass(cls *clazz) interface{} {
+	if cls == nil {
+		return nil
+	}
+
+	name := cls.name
+	if strings.HasPrefix(name, "[") {
+		name = javaTypeName(name)
+	}
+
+	if jop.classFormat != ClassReference {
+		return name
+	}
+
+	suid, _ := strconv.ParseUint(cls.serialVersionUID, 16, 64)
+	return map[string]interface{}{classRefField: name, classSUIDField: jop.outputLong(int64(suid))}
+}
+
+// javaTypeName decodes a JVM type signature or array class name into a java type name,
+// e.g. "Ljava/util/Map;" into "java.util.Map" and "[[I" into "int[][]".
+func javaTypeName(signature string) string {
+	dims := 0
+	for dims < len(signature) && signature[dims] == '[' {
+		dims++
+	}
+
+	name := signature[dims:]
+	switch {
+	case len(name) == 1 && primitiveTypeNames[name[0]] != "":
+		name = primitiveTypeNames[name[0]]
+	case strings.HasPrefix(name, "L") && strings.HasSuffix(name, ";"):
+		name = strings.ReplaceAll(name[1:len(name)-1], "/", ".")
+	}
+
+	return name + strings.Repeat("[]", dims)
+}
+
+// outputLong converts a java long according to the long format.
+func (jop *JavaObjectParser) outputLong(x int64) interface{} {
+	if jop.longFormat == LongString || jop.longFormat == LongStringUnsafe && (x > maxSafeInteger || x < -maxSafeInteger) {
+		return strconv.FormatInt(x, 10)
+	}
+
+	return x
+}
+
+// outputFloat converts a java float or double according to the non-finite format.
+func (jop *JavaObjectParser) outputFloat(value interface{}, x float64) interface{} {
+	if !math.IsNaN(x) && !math.IsInf(x, 0) {
+		return value
+	}
+
+	switch jop.nonFiniteFormat {
+	case NonFiniteString:
+		switch {
+		case math.IsNaN(x):
+			return "NaN"
+		case x > 0:
+			return "Infinity"
+		default:
+			return "-Infinity"
+		}
+	case NonFiniteNull:
+		return nil
+	}
+
+	return value
+}
+
+// outputMap converts java map entries into a map[string]interface{}, an *OrderedMap or a list of entries.
+func (jop *JavaObjectParser) outputMap(m javaMap) (res interface{}, err error) {
+	if jop.mapKeyPolicy == MapKeyEntries {
+		return jop.outputMapEntries(m)
+	}
+
+	keys := make([]interface{}, len(m))
+	names := make([]string, len(m))
+	seen := make(map[string]bool, len(m))
+
+	for i, entry := range m {
+		// enum objects are unusable as object keys, so enum keys are formatted as their qualified name instead
+		if e, isEnum := entry.key.(javaEnum); isEnum && jop.enumFormat == EnumObject {
+			keys[i] = qualifiedEnumName(e)
+		} else if keys[i], err = jop.outputKey(entry.key); err != nil {
+			return
+		}
+
+		if names[i], err = jop.mapKey(keys[i]); err != nil {
+			return
+		}
+
+		if seen[names[i]] && jop.mapKeyPolicy == MapKeyStrict {
+			err = errors.Errorf("map key collision: %q", names[i])
+			return
+		}
+
+		seen[names[i]] = true
+	}
+
+	// values are converted in the order they are marshalled, so shared values get their id on first appearance
+	order := make([]int, len(m))
+	for i := range order {
+		order[i] = i
+	}
+
+	if jop.references != nil && !jop.orderedMaps {
+		sort.SliceStable(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+	}
+
+	values := make([]interface{}, len(m))
+	for _, i := range order {
+		if values[i], err = jop.output(m[i].value); err != nil {
+			return
+		}
+	}
+
+	om := NewOrderedMap()
+	for i := range m {
+		om.Set(names[i], values[i])
+	}
+
+	if jop.orderedMaps {
+		return om, nil
+	}
+
+	return om.values, nil
+}
+
+// outputKey converts a map key formatted as a string, without references as ids inside the string cannot be referenced.
+func (jop *JavaObjectParser) outputKey(key interface{}) (interface{}, error) {
+	references := jop.references
+	jop.references = nil
+	defer func() { jop.references = references }()
+
+	return jop.output(key)
+}
+
+// outputMapEntries converts a map to a list of key/value entries, converting each key before its value
+// so shared objects get their id on first appearance in entry order.
+func (jop *JavaObjectParser) outputMapEntries(m javaMap) (res interface{}, err error) {
+	entries := make([]interface{}, len(m))
+	for i, entry := range m {
+		var key, value interface{}
+		if key, err = jop.output(entry.key); err != nil {
+			return
+		}
+
+		if value, err = jop.output(entry.value); err != nil {
+			return
+		}
+
+		entries[i] = map[string]interface{}{"key": key, "value": value}
+	}
+
+	return entries, nil
+}
+
+// objectKeys returns the keys of an object, sorted as marshalled when references are enabled.
+func (jop *JavaObjectParser) objectKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+
+	if jop.references != nil {
+		sort.Strings(keys)
+	}
+
+	return keys
+}
+
+// mapKey formats a java map key according to the map key policy.
+func (jop *JavaObjectParser) mapKey(key interface{}) (string, error) {
+	if jop.mapKeyPolicy == MapKeySprint {
+		return fmt.Sprint(key), nil
+	}
+
+	if s, isString := key.(string); isString {
+		return s, nil
+	}
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", errors.Wrap(err, "error encoding map key")
+	}
+
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s, nil
+	}
+
+	return string(b), nil
+}
+
+// outputBytes converts a java byte array according to the byte array format.
+func (jop *JavaObjectParser) outputBytes(b []byte) interface{} {
+	switch jop.byteArrayFormat {
+	case ByteArrayBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case ByteArrayHex:
+		return hex.EncodeToString(b)
+	case ByteArrayText:
+		if utf8.Valid(b) {
+			return string(b)
+		}
+
+		return base64.StdEncoding.EncodeToString(b)
+	}
+
+	numbers := make([]int8, len(b))
+	for i, x := range b {
+		numbers[i] = int8(x)
+	}
+
+	return numbers
+}
@@ -0,0 +1,188 @@
+package java2json
+
+import (
+	"reflect"
+	"strconv"
+)
+
+const referenceIDField string = "$id"
+const referenceField string = "$ref"
+const referenceValuesField string = "$values"
+
+// identity identifies a map or a non-empty slice shared between several parts of a parsed object.
+type identity struct {
+	kind    reflect.Kind
+	pointer uintptr
+	length  int
+}
+
+// identityOf returns the identity of objects, lists and maps, other values are treated as plain values.
+func identityOf(value interface{}) (id identity, hasIdentity bool) {
+	switch v := value.(type) {
+	case map[string]interface{}, *OrderedMap:
+	case []interface{}:
+		if len(v) == 0 {
+			return
+		}
+	case javaMap:
+		if len(v) == 0 {
+			return
+		}
+	default:
+		return
+	}
+
+	rv := reflect.ValueOf(value)
+	id = identity{kind: rv.Kind(), pointer: rv.Pointer()}
+	if rv.Kind() == reflect.Slice {
+		id.length = rv.Len()
+	}
+
+	return id, true
+}
+
+// sameValue reports whether a and b are the same object, or equal plain values.
+func sameValue(a, b interface{}) bool {
+	if idA, hasIdentity := identityOf(a); hasIdentity {
+		idB, hasIdentity := identityOf(b)
+		return hasIdentity && idA == idB
+	}
+
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// countReferences counts how many times each object is reached from value, cyclic references count twice
+// so the referenced object always gets an id.
+func (jop *JavaObjectParser) countReferences(value interface{}) {
+	if id, hasIdentity := identityOf(value); hasIdentity {
+		jop.references[id]++
+		if jop.references[id] > 1 {
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, val := range v {
+			jop.countReferences(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			jop.countReferences(val)
+		}
+	case *OrderedMap:
+		for _, val := range v.values {
+			jop.countReferences(val)
+		}
+	case javaMap:
+		for i, entry := range v {
+			if jop.mapKeyPolicy == MapKeyEntries {
+				jop.countReferences(entry.key)
+			} else {
+				// keys formatted as strings cannot hold an id nor be referenced, so they are detached from shared objects
+				v[i].key = copyValue(entry.key)
+			}
+
+			jop.countReferences(entry.value)
+		}
+	case cycleReference:
+		if id, hasIdentity := identityOf(jop.handleValue(int(v))); hasIdentity {
+			jop.references[id] += 2
+		}
+	}
+}
+
+// copyValue returns a deep copy of an intermediate value, so converting the copy leaves the original untouched.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			c[key] = copyValue(val)
+		}
+
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, val := range v {
+			c[i] = copyValue(val)
+		}
+
+		return c
+	case *OrderedMap:
+		c := NewOrderedMap()
+		for _, key := range v.keys {
+			c.Set(key, copyValue(v.values[key]))
+		}
+
+		return c
+	case javaMap:
+		c := make(javaMap, len(v))
+		for i, entry := range v {
+			c[i] = mapEntry{key: copyValue(entry.key), value: copyValue(entry.value)}
+		}
+
+		return c
+	}
+
+	return value
+}
+
+// handleValue returns the value of an object handle as returned by content.
+func (jop *JavaObjectParser) handleValue(handle int) interface{} {
+	value := jop.handles[handle]
+	if valueMap, isMap := value.(map[string]interface{}); isMap {
+		if objectValue, exists := valueMap[objectValueField]; exists {
+			return objectValue
+		}
+	}
+
+	return value
+}
+
+// outputIdentified converts the first appearance of a shared object adding its "$id",
+// later appearances are converted into a {"$ref": id} object.
+func (jop *JavaObjectParser) outputIdentified(id identity, value interface{}) (interface{}, error) {
+	if refID, exists := jop.referenceIDs[id]; exists {
+		return map[string]interface{}{referenceField: refID}, nil
+	}
+
+	refID := strconv.Itoa(len(jop.referenceIDs) + 1)
+	jop.referenceIDs[id] = refID
+
+	res, err := jop.outputValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := res.(type) {
+	case map[string]interface{}:
+		r[referenceIDField] = refID
+		return r, nil
+	case *OrderedMap:
+		om := NewOrderedMap()
+		om.Set(referenceIDField, refID)
+		for _, key := range r.keys {
+			om.Set(key, r.values[key])
+		}
+
+		return om, nil
+	}
+
+	return map[string]interface{}{referenceIDField: refID, referenceValuesField: res}, nil
+}
+
+// outputCycleReference converts a cyclic reference into a {"$ref": id} object.
+func (jop *JavaObjectParser) outputCycleReference(handle int) interface{} {
+	if id, hasIdentity := identityOf(jop.handleValue(handle)); hasIdentity {
+		if refID, exists := jop.referenceIDs[id]; exists {
+			return map[string]interface{}{referenceField: refID}
+		}
+	}
+
+	return jop.cycleReferenceValue
+}
@@ -0,0 +1,139 @@
+package java2json
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// scalaHandlerPack unwraps Scala collection serialization proxies, options and tuples.
+var scalaHandlerPack = handlerPack{
+	postProcs: map[string]postProc{
+		"scala.collection.immutable.List$SerializationProxy": scalaListProxyPostProc,
+		"scala.collection.immutable.ListSerializeEnd$":       scalaEndMarkerPostProc,
+		"scala.collection.generic.DefaultSerializationProxy": scalaDefaultProxyPostProc,
+		"scala.collection.generic.SerializeEnd$":             scalaEndMarkerPostProc,
+		"scala.collection.MapFactory$ToFactory":              scalaMapFactoryPostProc,
+		"scala.collection.SortedMapFactory$ToFactory":        scalaMapFactoryPostProc,
+		"scala.Some":  scalaSomePostProc,
+		"scala.None$": scalaNonePostProc,
+	},
+	objectPostProcs: map[string]objectPostProc{
+		"scala.Tuple2": scalaTuple2PostProc,
+	},
+}
+
+// scalaEndMarker replaces the end marker objects written after the elements of Scala collections.
+type scalaEndMarker struct{}
+
+// scalaMapFactory replaces the factories of Scala maps, so their serialization proxy elements become map entries.
+type scalaMapFactory struct{}
+
+// scalaEndMarkerPostProc populates the object value with a scalaEndMarker.
+func scalaEndMarkerPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = scalaEndMarker{}
+	return fields, nil
+}
+
+// scalaMapFactoryPostProc populates the object value with a scalaMapFactory.
+func scalaMapFactoryPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = scalaMapFactory{}
+	return fields, nil
+}
+
+// scalaListProxyPostProc populates the object value with a []interface{} terminated by an end marker.
+func scalaListProxyPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	elements, err := scalaEndMarkedElements(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[objectValueField] = elements
+	return fields, nil
+}
+
+// scalaEndMarkedElements returns the elements preceding the end marker.
+func scalaEndMarkedElements(data []interface{}) ([]interface{}, error) {
+	for i, elem := range data {
+		if _, isEnd := elem.(scalaEndMarker); isEnd {
+			return data[:i], nil
+		}
+	}
+
+	return nil, errors.New("invalid data: missing end marker")
+}
+
+// scalaDefaultProxyPostProc populates the object value with a []interface{}, or with a map when the "factory" field
+// is a map factory, the known size precedes the elements and an end marker follows them when the size is unknown.
+func scalaDefaultProxyPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	size, err := postProcSize(data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := data[1:]
+	if size < 0 {
+		if elements, err = scalaEndMarkedElements(elements); err != nil {
+			return nil, err
+		}
+	} else if len(elements) != size {
+		return nil, errors.Errorf("incorrect number of elements: want %d got %d", size, len(elements))
+	}
+
+	if _, isMap := fields["factory"].(scalaMapFactory); !isMap {
+		fields[objectValueField] = elements
+		return fields, nil
+	}
+
+	m := make(javaMap, len(elements))
+	for i, elem := range elements {
+		pair, isPair := elem.([]interface{})
+		if !isPair || len(pair) != 2 {
+			return nil, errors.Errorf("unexpected map entry at position %d", i)
+		}
+
+		m[i] = mapEntry{key: pair[0], value: pair[1]}
+	}
+
+	fields[objectValueField] = m
+	return fields, nil
+}
+
+// scalaSomePostProc populates the object value with the "value" field, named "x" before Scala 2.12.
+func scalaSomePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	if value, exists := fields["value"]; exists {
+		fields[objectValueField] = value
+	} else {
+		fields[objectValueField] = fields["x"]
+	}
+
+	return fields, nil
+}
+
+// scalaNonePostProc populates the object value with nil.
+func scalaNonePostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = nil
+	return fields, nil
+}
+
+// scalaTuple2PostProc populates the object value with a []interface{} of both tuple elements,
+// specialized tuples hold primitive elements in "_1$mc?$sp" fields.
+func scalaTuple2PostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	obj[objectValueField] = []interface{}{scalaTupleElement(obj, "_1"), scalaTupleElement(obj, "_2")}
+	return obj, nil
+}
+
+// scalaTupleElement returns the named tuple element, or its specialized field when it is nil.
+func scalaTupleElement(obj map[string]interface{}, name string) interface{} {
+	if value := obj[name]; value != nil {
+		return value
+	}
+
+	for key, value := range obj {
+		if strings.HasPrefix(key, name+"$mc") {
+			return value
+		}
+	}
+
+	return nil
+}
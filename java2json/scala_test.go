@@ -0,0 +1,35 @@
+package java2json
+
+import "testing"
+
+func TestScalaListSerializationProxy(t *testing.T) {
+	input := "rO0ABXNyADJzY2FsYS5jb2xsZWN0aW9uLmltbXV0YWJsZS5MaXN0JFNlcmlhbGl6YXRpb25Qcm94eQAAAAAAAAABAwAAeHB0AAFhdAABYnNyACxzY2FsYS5jb2xsZWN0aW9uLmltbXV0YWJsZS5MaXN0U2VyaWFsaXplRW5kJIpcY8Dyoc9aAgAAeHB4"
+	expected := `["a","b"]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(ScalaPack)
+	})
+}
+
+func TestScalaDefaultSerializationProxyMap(t *testing.T) {
+	input := "rO0ABXNyADJzY2FsYS5jb2xsZWN0aW9uLmdlbmVyaWMuRGVmYXVsdFNlcmlhbGl6YXRpb25Qcm94eQAAAAAAAAADAwABTAAHZmFjdG9yeXQAGkxzY2FsYS9jb2xsZWN0aW9uL0ZhY3Rvcnk7eHBzcgAlc2NhbGEuY29sbGVjdGlvbi5NYXBGYWN0b3J5JFRvRmFjdG9yeQAAAAAAAAADAgABTAAHZmFjdG9yeXQAHUxzY2FsYS9jb2xsZWN0aW9uL01hcEZhY3Rvcnk7eHBzcgAjc2NhbGEuY29sbGVjdGlvbi5pbW11dGFibGUuSGFzaE1hcCQAAAAAAAAAAwIAAHhwdwT/////c3IADHNjYWxhLlR1cGxlMsSzwvw/WwodAgACTAACXzF0ABJMamF2YS9sYW5nL09iamVjdDtMAAJfMnEAfgAJeHB0AAFhc3IAEWphdmEubGFuZy5JbnRlZ2VyEuKgpPeBhzgCAAFJAAV2YWx1ZXhyABBqYXZhLmxhbmcuTnVtYmVyhqyVHQuU4IsCAAB4cAAAAAFzcQB+AAh0AAFic3EAfgAMAAAAAnNyACZzY2FsYS5jb2xsZWN0aW9uLmdlbmVyaWMuU2VyaWFsaXplRW5kJAAAAAAAAAADAgAAeHB4"
+	expected := `{"a":1,"b":2}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(ScalaPack)
+	})
+}
+
+func TestScalaDefaultSerializationProxyOptions(t *testing.T) {
+	input := "rO0ABXNyADJzY2FsYS5jb2xsZWN0aW9uLmdlbmVyaWMuRGVmYXVsdFNlcmlhbGl6YXRpb25Qcm94eQAAAAAAAAADAwABTAAHZmFjdG9yeXQAGkxzY2FsYS9jb2xsZWN0aW9uL0ZhY3Rvcnk7eHBzcgAqc2NhbGEuY29sbGVjdGlvbi5JdGVyYWJsZUZhY3RvcnkkVG9GYWN0b3J5AAAAAAAAAAMCAAFMAAdmYWN0b3J5dAAiTHNjYWxhL2NvbGxlY3Rpb24vSXRlcmFibGVGYWN0b3J5O3hwc3IAInNjYWxhLmNvbGxlY3Rpb24uaW1tdXRhYmxlLlZlY3RvciQAAAAAAAAAAwIAAHhwdwQAAAACc3IACnNjYWxhLlNvbWURIjNEVWZ3iAIAAUwABXZhbHVldAASTGphdmEvbGFuZy9PYmplY3Q7eHB0AAF4c3IAC3NjYWxhLk5vbmUkRVngHdaptlsCAAB4cHg="
+	expected := `["x",null]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(ScalaPack)
+	})
+}
+
+func TestScalaSpecializedTuple2(t *testing.T) {
+	input := "rO0ABXNyABRzY2FsYS5UdXBsZTIkbWNJSSRzcAAAAAAAAAABAgACSQAJXzEkbWNJJHNwSQAJXzIkbWNJJHNweHIADHNjYWxhLlR1cGxlMsSzwvw/WwodAgACTAACXzF0ABJMamF2YS9sYW5nL09iamVjdDtMAAJfMnEAfgACeHBwcAAAAAEAAAAC"
+	expected := `[1,2]`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(ScalaPack)
+	})
+}
@@ -0,0 +1,84 @@
+package java2json
+
+// springSecurityHandlerPack renders Spring Security contexts, authentication tokens, users and granted authorities,
+// and Spring Session sessions.
+var springSecurityHandlerPack = handlerPack{
+	postProcs: map[string]postProc{
+		"org.springframework.security.core.authority.SimpleGrantedAuthority": fieldPostProc("role"),
+		"org.springframework.security.core.context.SecurityContextImpl":      fieldPostProc("authentication"),
+		"org.springframework.security.oauth2.core.user.DefaultOAuth2User":    oauth2UserPostProc,
+		"org.springframework.session.MapSession":                             mapSessionPostProc,
+	},
+	objectPostProcs: map[string]objectPostProc{
+		"org.springframework.security.authentication.AbstractAuthenticationToken": authenticationTokenPostProc,
+		"org.springframework.security.core.userdetails.User":                      userDetailsPostProc,
+	},
+}
+
+// authenticationTokenPostProc populates the object value with the principal, authorities, authentication status
+// and details of an authentication token, credentials are left out.
+func authenticationTokenPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	authorities := obj["authorities"]
+	if authorities == nil {
+		authorities = []interface{}{}
+	}
+
+	obj[objectValueField] = map[string]interface{}{
+		"principal":     obj["principal"],
+		"authorities":   authorities,
+		"authenticated": obj["authenticated"],
+		"details":       obj["details"],
+	}
+
+	return obj, nil
+}
+
+// userDetailsPostProc populates the object value with the username, authorities and account status of a user,
+// the password is left out.
+func userDetailsPostProc(cls *clazz, obj map[string]interface{}, handle int) (map[string]interface{}, error) {
+	obj[objectValueField] = map[string]interface{}{
+		"username":              obj["username"],
+		"authorities":           obj["authorities"],
+		"enabled":               obj["enabled"],
+		"accountNonExpired":     obj["accountNonExpired"],
+		"accountNonLocked":      obj["accountNonLocked"],
+		"credentialsNonExpired": obj["credentialsNonExpired"],
+	}
+
+	return obj, nil
+}
+
+// oauth2UserPostProc populates the object value with the name, authorities and attributes of an OAuth 2.0 user,
+// the name is the attribute named by the "nameAttributeKey" field, it also applies to OIDC users extending it.
+func oauth2UserPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	var name interface{}
+	if attributes, isMap := fields["attributes"].(javaMap); isMap {
+		for _, entry := range attributes {
+			if sameValue(entry.key, fields["nameAttributeKey"]) {
+				name = entry.value
+				break
+			}
+		}
+	}
+
+	fields[objectValueField] = map[string]interface{}{
+		"name":        name,
+		"authorities": fields["authorities"],
+		"attributes":  fields["attributes"],
+	}
+
+	return fields, nil
+}
+
+// mapSessionPostProc populates the object value with the id, timestamps, timeout and attributes of a session.
+func mapSessionPostProc(fields map[string]interface{}, data []interface{}) (map[string]interface{}, error) {
+	fields[objectValueField] = map[string]interface{}{
+		"id":                  fields["id"],
+		"creationTime":        fields["creationTime"],
+		"lastAccessedTime":    fields["lastAccessedTime"],
+		"maxInactiveInterval": fields["maxInactiveInterval"],
+		"attributes":          fields["sessionAttrs"],
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,34 @@
+package java2json
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSpringSecurityContext(t *testing.T) {
+	input := "rO0ABXNyAD1vcmcuc3ByaW5nZnJhbWV3b3JrLnNlY3VyaXR5LmNvcmUuY29udGV4dC5TZWN1cml0eUNvbnRleHRJbXBsAAAAAAAAAmwCAAFMAA5hdXRoZW50aWNhdGlvbnQAMkxvcmcvc3ByaW5nZnJhbWV3b3JrL3NlY3VyaXR5L2NvcmUvQXV0aGVudGljYXRpb247eHBzcgBPb3JnLnNwcmluZ2ZyYW1ld29yay5zZWN1cml0eS5hdXRoZW50aWNhdGlvbi5Vc2VybmFtZVBhc3N3b3JkQXV0aGVudGljYXRpb25Ub2tlbgAAAAAAAAJsAgACTAALY3JlZGVudGlhbHN0ABJMamF2YS9sYW5nL09iamVjdDtMAAlwcmluY2lwYWxxAH4ABHhyAEdvcmcuc3ByaW5nZnJhbWV3b3JrLnNlY3VyaXR5LmF1dGhlbnRpY2F0aW9uLkFic3RyYWN0QXV0aGVudGljYXRpb25Ub2tlbgAAAAAAAAJsAgADWgANYXV0aGVudGljYXRlZEwAC2F1dGhvcml0aWVzdAAWTGphdmEvdXRpbC9Db2xsZWN0aW9uO0wAB2RldGFpbHNxAH4ABHhwAXNyACZqYXZhLnV0aWwuQ29sbGVjdGlvbnMkVW5tb2RpZmlhYmxlTGlzdPwPJTG17I4QAgABTAAEbGlzdHQAEExqYXZhL3V0aWwvTGlzdDt4cgAsamF2YS51dGlsLkNvbGxlY3Rpb25zJFVubW9kaWZpYWJsZUNvbGxlY3Rpb24ZQgCAy173HgIAAUwAAWNxAH4ABnhwc3IAE2phdmEudXRpbC5BcnJheUxpc3R4gdIdmcdhnQMAAUkABHNpemV4cAAAAAJ3BAAAAAJzcgBCb3JnLnNwcmluZ2ZyYW1ld29yay5zZWN1cml0eS5jb3JlLmF1dGhvcml0eS5TaW1wbGVHcmFudGVkQXV0aG9yaXR5AAAAAAAAAmwCAAFMAARyb2xldAASTGphdmEvbGFuZy9TdHJpbmc7eHB0AApST0xFX0FETUlOc3EAfgAOdAAJUk9MRV9VU0VSeHEAfgANc3IASG9yZy5zcHJpbmdmcmFtZXdvcmsuc2VjdXJpdHkud2ViLmF1dGhlbnRpY2F0aW9uLldlYkF1dGhlbnRpY2F0aW9uRGV0YWlscwAAAAAAAAJsAgACTAANcmVtb3RlQWRkcmVzc3EAfgAPTAAJc2Vzc2lvbklkcQB+AA94cHQACDEwLjAuMC4xdAACUzFwc3IAMm9yZy5zcHJpbmdmcmFtZXdvcmsuc2VjdXJpdHkuY29yZS51c2VyZGV0YWlscy5Vc2VyAAAAAAAAAmwCAAdaABFhY2NvdW50Tm9uRXhwaXJlZFoAEGFjY291bnROb25Mb2NrZWRaABVjcmVkZW50aWFsc05vbkV4cGlyZWRaAAdlbmFibGVkTAALYXV0aG9yaXRpZXN0AA9MamF2YS91dGlsL1NldDtMAAhwYXNzd29yZHEAfgAPTAAIdXNlcm5hbWVxAH4AD3hwAQEBAXNyACVqYXZhLnV0aWwuQ29sbGVjdGlvbnMkVW5tb2RpZmlhYmxlU2V0gB2S0Y+bgFUCAAB4cQB+AApzcgARamF2YS51dGlsLlRyZWVTZXTdmFCTle2HWwMAAHhwc3IARm9yZy5zcHJpbmdmcmFtZXdvcmsuc2VjdXJpdHkuY29yZS51c2VyZGV0YWlscy5Vc2VyJEF1dGhvcml0eUNvbXBhcmF0b3IAAAAAAAACbAIAAHhwdwQAAAACc3EAfgAOcQB+ABFzcQB+AA5xAH4AE3hwdAAFYWxpY2U="
+	expected := `{"authenticated":true,"authorities":["ROLE_ADMIN","ROLE_USER"],"details":{"remoteAddress":"10.0.0.1","sessionId":"S1"},"principal":{"accountNonExpired":true,"accountNonLocked":true,"authorities":["ROLE_ADMIN","ROLE_USER"],"credentialsNonExpired":true,"enabled":true,"username":"alice"}}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(SpringSecurityPack)
+	})
+}
+
+func TestSpringSessionMapSession(t *testing.T) {
+	input := "rO0ABXNyACZvcmcuc3ByaW5nZnJhbWV3b3JrLnNlc3Npb24uTWFwU2Vzc2lvbgAAAAAAAAABAgAGTAAMY3JlYXRpb25UaW1ldAATTGphdmEvdGltZS9JbnN0YW50O0wAAmlkdAASTGphdmEvbGFuZy9TdHJpbmc7TAAQbGFzdEFjY2Vzc2VkVGltZXEAfgABTAATbWF4SW5hY3RpdmVJbnRlcnZhbHQAFExqYXZhL3RpbWUvRHVyYXRpb247TAAKb3JpZ2luYWxJZHEAfgACTAAMc2Vzc2lvbkF0dHJzdAAPTGphdmEvdXRpbC9NYXA7eHBzcgANamF2YS50aW1lLlNlcpVdhLobIkiyDAAAeHB3DQIAAAAAXg1dpQBbjYB4dAACczFzcQB+AAZ3DQIAAAAAXg1d4QAAAAB4c3EAfgAGdw0BAAAAAAAABwgAAAAAeHEAfgAIc3IAEWphdmEudXRpbC5IYXNoTWFwBQfawcMWYNEDAAJGAApsb2FkRmFjdG9ySQAJdGhyZXNob2xkeHA/QAAAAAAADHcIAAAAEAAAAAF0AAFrdAABdng="
+	creationTime, _ := json.Marshal(time.Unix(1577934245, 6000000))
+	lastAccessedTime, _ := json.Marshal(time.Unix(1577934305, 0))
+	expected := `{"attributes":{"k":"v"},"creationTime":` + string(creationTime) + `,"id":"s1",` +
+		`"lastAccessedTime":` + string(lastAccessedTime) + `,"maxInactiveInterval":"PT30M"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(SpringSecurityPack)
+	})
+}
+
+func TestSpringSecurityOidcUser(t *testing.T) {
+	input := "rO0ABXNyAEJvcmcuc3ByaW5nZnJhbWV3b3JrLnNlY3VyaXR5Lm9hdXRoMi5jb3JlLm9pZGMudXNlci5EZWZhdWx0T2lkY1VzZXIAAAAAAAACMAIAAkwAB2lkVG9rZW50ADtMb3JnL3NwcmluZ2ZyYW1ld29yay9zZWN1cml0eS9vYXV0aDIvY29yZS9vaWRjL09pZGNJZFRva2VuO0wACHVzZXJJbmZvdAA8TG9yZy9zcHJpbmdmcmFtZXdvcmsvc2VjdXJpdHkvb2F1dGgyL2NvcmUvb2lkYy9PaWRjVXNlckluZm87eHIAP29yZy5zcHJpbmdmcmFtZXdvcmsuc2VjdXJpdHkub2F1dGgyLmNvcmUudXNlci5EZWZhdWx0T0F1dGgyVXNlcgAAAAAAAAIwAgADTAAKYXR0cmlidXRlc3QAD0xqYXZhL3V0aWwvTWFwO0wAC2F1dGhvcml0aWVzdAAPTGphdmEvdXRpbC9TZXQ7TAAQbmFtZUF0dHJpYnV0ZUtleXQAEkxqYXZhL2xhbmcvU3RyaW5nO3hwc3IAEWphdmEudXRpbC5IYXNoTWFwBQfawcMWYNEDAAJGAApsb2FkRmFjdG9ySQAJdGhyZXNob2xkeHA/QAAAAAAADHcIAAAAEAAAAAJ0AANzdWJ0AAMxMjN0AAVlbWFpbHQABWFAYi5jeHNyABFqYXZhLnV0aWwuSGFzaFNldLpEhZWWuLc0AwAAeHB3DAAAABA/QAAAAAAAAXQACU9JRENfVVNFUnhxAH4ACnBw"
+	expected := `{"attributes":{"email":"a@b.c","sub":"123"},"authorities":["OIDC_USER"],"name":"123"}`
+	parseInputWithParserAndCompareResult(t, input, expected, func(jop *JavaObjectParser) {
+		jop.EnableHandlerPacks(SpringSecurityPack)
+	})
+}
@@ -32,8 +32,11 @@ func main() {
 	reader := bytes.NewReader(javaObjectBytes)
 	jop := java2json.NewJavaObjectParser(reader)
 
-	jop.SetMaxDataBlockSize(2048)                 // (optional) set max data block size
-	jop.SetCycleReferenceValue("cycle reference") // (optional) set cycle reference value
+	jop.SetMaxDataBlockSize(2048)                     // (optional) set max data block size
+	jop.SetCycleReferenceValue("cycle reference")     // (optional) set cycle reference value
+	jop.SetOrderedMaps(true)                          // (optional) keep the order of java maps
+	jop.SetLongFormat(java2json.LongStringUnsafe)     // (optional) return longs beyond 2^53 as strings
+	jop.SetNonFiniteFormat(java2json.NonFiniteString) // (optional) return NaN and Infinity as strings
 
 	obj, err = jop.ParseJavaObject()
 	if err != nil {